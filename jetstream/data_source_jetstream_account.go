@@ -0,0 +1,160 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nats-io/jsm.go/api"
+)
+
+// accountTierSchema describes the usage and limits of an account or one of its tiers
+func accountTierSchema() map[string]*schema.Schema {
+	computedInt := func(description string) *schema.Schema {
+		return &schema.Schema{Type: schema.TypeInt, Description: description, Computed: true}
+	}
+
+	return map[string]*schema.Schema{
+		"memory":                   computedInt("The bytes of memory used by streams"),
+		"storage":                  computedInt("The bytes of file storage used by streams"),
+		"reserved_memory":          computedInt("The bytes of memory reserved by streams with max_bytes set"),
+		"reserved_storage":         computedInt("The bytes of file storage reserved by streams with max_bytes set"),
+		"streams":                  computedInt("The number of streams"),
+		"consumers":                computedInt("The number of consumers"),
+		"max_memory":               computedInt("The maximum bytes of memory that may be used, -1 for unlimited"),
+		"max_storage":              computedInt("The maximum bytes of file storage that may be used, -1 for unlimited"),
+		"max_streams":              computedInt("The maximum number of streams, -1 for unlimited"),
+		"max_consumers":            computedInt("The maximum number of consumers, -1 for unlimited"),
+		"max_ack_pending":          computedInt("The maximum outstanding acks per consumer, -1 for unlimited"),
+		"memory_max_stream_bytes":  computedInt("The maximum max_bytes of a memory stream, 0 or -1 for unlimited"),
+		"storage_max_stream_bytes": computedInt("The maximum max_bytes of a file stream, 0 or -1 for unlimited"),
+		"max_bytes_required": {
+			Type:        schema.TypeBool,
+			Description: "If streams must be created with max_bytes set",
+			Computed:    true,
+		},
+	}
+}
+
+func dataSourceAccount() *schema.Resource {
+	s := accountTierSchema()
+
+	tier := accountTierSchema()
+	tier["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The name of the tier like R1 or R3",
+		Computed:    true,
+	}
+
+	s["domain"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The JetStream domain the account is connected to",
+		Computed:    true,
+	}
+	s["api_level"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The JetStream API level supported by the server",
+		Computed:    true,
+	}
+	s["api_requests"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The number of JetStream API requests made by the account",
+		Computed:    true,
+	}
+	s["api_errors"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The number of JetStream API requests made by the account that failed",
+		Computed:    true,
+	}
+	s["tiers"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The usage and limits of each tier when the account uses tiered limits, sorted by name",
+		Computed:    true,
+		Elem:        &schema.Resource{Schema: tier},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceAccountRead,
+		Schema:      s,
+	}
+}
+
+func accountTierData(tier api.JetStreamTier) map[string]any {
+	return map[string]any{
+		"memory":                   int(tier.Memory),
+		"storage":                  int(tier.Store),
+		"reserved_memory":          int(tier.ReservedMemory),
+		"reserved_storage":         int(tier.ReservedStore),
+		"streams":                  tier.Streams,
+		"consumers":                tier.Consumers,
+		"max_memory":               int(tier.Limits.MaxMemory),
+		"max_storage":              int(tier.Limits.MaxStore),
+		"max_streams":              tier.Limits.MaxStreams,
+		"max_consumers":            tier.Limits.MaxConsumers,
+		"max_ack_pending":          tier.Limits.MaxAckPending,
+		"memory_max_stream_bytes":  int(tier.Limits.MemoryMaxStreamBytes),
+		"storage_max_stream_bytes": int(tier.Limits.StoreMaxStreamBytes),
+		"max_bytes_required":       tier.Limits.MaxBytesRequired,
+	}
+}
+
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	mgr, err := getManager(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	info, err := retryValue(ctx, func(context.Context) (*api.JetStreamAccountStats, error) { return mgr.JetStreamAccountInfo() })
+	if err != nil {
+		return diag.Errorf("could not load account information: %s", err)
+	}
+
+	level, err := apiLevel(mgr)
+	if err != nil {
+		return diag.Errorf("could not determine the JetStream API level: %s", err)
+	}
+
+	for k, v := range accountTierData(info.JetStreamTier) {
+		d.Set(k, v)
+	}
+
+	var names []string
+	for name := range info.Tiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tiers := make([]map[string]any, len(names))
+	for i, name := range names {
+		tiers[i] = accountTierData(info.Tiers[name])
+		tiers[i]["name"] = name
+	}
+
+	d.Set("tiers", tiers)
+	d.Set("domain", info.Domain)
+	d.Set("api_level", int(level))
+	d.Set("api_requests", int(info.API.Total))
+	d.Set("api_errors", int(info.API.Errors))
+
+	if info.Domain != "" {
+		d.SetId(joinID(info.Domain))
+	} else {
+		d.SetId("account")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats.go"
+)
+
+const testDataSourceAccount = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_account" "test" {
+  lifecycle {
+    postcondition {
+      condition     = self.max_streams == -1 || self.streams < self.max_streams
+      error_message = "no streams can be added to the account"
+    }
+  }
+}
+`
+
+func TestDataSourceAccount(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	checkErr(t, err, "could not create manager: %v", err)
+
+	_, err = mgr.NewStream("ORDERS", jsm.Subjects("ORDERS.*"), jsm.MemoryStorage(), jsm.MaxBytes(1024))
+	checkErr(t, err, "could not create stream: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceAccount, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetstream_account.test", "id", "account"),
+					resource.TestCheckResourceAttr("data.jetstream_account.test", "streams", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_account.test", "consumers", "0"),
+					resource.TestCheckResourceAttr("data.jetstream_account.test", "reserved_memory", "1024"),
+					resource.TestCheckResourceAttr("data.jetstream_account.test", "max_streams", "-1"),
+					resource.TestCheckResourceAttr("data.jetstream_account.test", "tiers.#", "0"),
+					resource.TestCheckResourceAttrSet("data.jetstream_account.test", "api_level"),
+					resource.TestCheckResourceAttrSet("data.jetstream_account.test", "api_requests"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+)
+
+// consumerStateSchema describes the live state of a consumer
+func consumerStateSchema() map[string]*schema.Schema {
+	computedInt := func(description string) *schema.Schema {
+		return &schema.Schema{Type: schema.TypeInt, Description: description, Computed: true}
+	}
+
+	return map[string]*schema.Schema{
+		"num_pending":                 computedInt("The number of messages in the stream not yet delivered to the consumer"),
+		"num_ack_pending":             computedInt("The number of messages delivered but not yet acknowledged"),
+		"num_redelivered":             computedInt("The number of messages that were delivered more than once"),
+		"num_waiting":                 computedInt("The number of outstanding pull requests"),
+		"delivered_consumer_sequence": computedInt("The consumer sequence of the last delivered message"),
+		"delivered_stream_sequence":   computedInt("The stream sequence of the last delivered message"),
+		"ack_floor_consumer_sequence": computedInt("The consumer sequence below which all messages are acknowledged"),
+		"ack_floor_stream_sequence":   computedInt("The stream sequence below which all messages are acknowledged"),
+		"cluster_leader": {
+			Type:        schema.TypeString,
+			Description: "The server currently leading the consumer when clustered",
+			Computed:    true,
+		},
+		"created": {
+			Type:        schema.TypeString,
+			Description: "When the consumer was created, in RFC3339 format",
+			Computed:    true,
+		},
+		"paused": {
+			Type:        schema.TypeBool,
+			Description: "If the consumer is paused",
+			Computed:    true,
+		},
+		"push_bound": {
+			Type:        schema.TypeBool,
+			Description: "If a client is bound to the delivery subject of a push consumer",
+			Computed:    true,
+		},
+	}
+}
+
+func consumerStateData(nfo api.ConsumerInfo) map[string]any {
+	state := map[string]any{
+		"num_pending":                 int(nfo.NumPending),
+		"num_ack_pending":             nfo.NumAckPending,
+		"num_redelivered":             nfo.NumRedelivered,
+		"num_waiting":                 nfo.NumWaiting,
+		"delivered_consumer_sequence": int(nfo.Delivered.Consumer),
+		"delivered_stream_sequence":   int(nfo.Delivered.Stream),
+		"ack_floor_consumer_sequence": int(nfo.AckFloor.Consumer),
+		"ack_floor_stream_sequence":   int(nfo.AckFloor.Stream),
+		"cluster_leader":              "",
+		"created":                     nfo.Created.Format(time.RFC3339),
+		"paused":                      nfo.Paused,
+		"push_bound":                  nfo.PushBound,
+	}
+
+	if nfo.Cluster != nil {
+		state["cluster_leader"] = nfo.Cluster.Leader
+	}
+
+	return state
+}
+
+func dataSourceConsumer() *schema.Resource {
+	s := dataSourceSchemaFromResource(resourceConsumer().Schema, nil, "wait_for_healthy", "healthy_max_lag")
+
+	s["stream"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The name of the stream the consumer belongs to",
+		Required:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+	}
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The name of the consumer to read",
+		Required:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+	}
+
+	for k, v := range consumerStateSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceConsumerRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceConsumerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	stream := d.Get("stream").(string)
+	name := d.Get("name").(string)
+
+	mgr, err := getManager(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	known, err := retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownStream(stream) })
+	if err != nil {
+		return diag.Errorf("could not determine if stream %q is known: %s", stream, err)
+	}
+	if !known {
+		return diag.Errorf("stream %q does not exist", stream)
+	}
+
+	known, err = retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownConsumer(stream, name) })
+	if err != nil {
+		return diag.Errorf("could not determine if %q > %q is a known consumer: %s", stream, name, err)
+	}
+	if !known {
+		return diag.Errorf("consumer %q > %q does not exist", stream, name)
+	}
+
+	cons, err := retryValue(ctx, func(context.Context) (*jsm.Consumer, error) { return mgr.LoadConsumer(stream, name) })
+	if err != nil {
+		return diag.Errorf("could not load consumer %q > %q: %s", stream, name, err)
+	}
+
+	err = setConsumerResourceData(d, stream, cons)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nfo, err := cons.LatestState()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for k, v := range consumerStateData(nfo) {
+		d.Set(k, v)
+	}
+
+	// matches the resource id
+	d.SetId(consumerID(stream, name))
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+)
+
+const testDataSourceConsumer = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_consumer" "test" {
+  stream = "ORDERS"
+  name   = "NEW"
+}
+`
+
+const testDataSourceConsumerMissing = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_consumer" "test" {
+  stream = "ORDERS"
+  name   = "MISSING"
+}
+`
+
+func TestDataSourceConsumer(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	checkErr(t, err, "could not create manager: %v", err)
+
+	_, err = mgr.NewStream("ORDERS", jsm.Subjects("ORDERS.*"))
+	checkErr(t, err, "could not create stream: %v", err)
+
+	_, err = mgr.NewConsumer("ORDERS", jsm.DurableName("NEW"), jsm.FilterStreamBySubject("ORDERS.new"), jsm.AckWait(time.Minute), jsm.MaxRequestBatch(1), jsm.ConsumerMetadata(map[string]string{"team": "orders"}))
+	checkErr(t, err, "could not create consumer: %v", err)
+
+	for i := 0; i < 5; i++ {
+		_, err = nc.Request("ORDERS.new", []byte("order"), time.Second)
+		checkErr(t, err, "could not publish: %v", err)
+	}
+
+	// deliver two messages and ack only the first
+	next := fmt.Sprintf(api.JSApiRequestNextT, "ORDERS", "NEW")
+	msg, err := nc.Request(next, nil, time.Second)
+	checkErr(t, err, "could not fetch message: %v", err)
+	err = msg.AckSync()
+	checkErr(t, err, "could not ack message: %v", err)
+
+	_, err = nc.Request(next, nil, time.Second)
+	checkErr(t, err, "could not fetch message: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceConsumer, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "id", "ORDERS/NEW"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "stream_id", "ORDERS"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "durable_name", "NEW"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "filter_subject", "ORDERS.new"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "ack_wait", "60"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "ack_policy", "explicit"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "metadata.team", "orders"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "num_pending", "3"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "num_ack_pending", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "delivered_stream_sequence", "2"),
+					resource.TestCheckResourceAttr("data.jetstream_consumer.test", "ack_floor_stream_sequence", "1"),
+					resource.TestCheckResourceAttrSet("data.jetstream_consumer.test", "created"),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testDataSourceConsumerMissing, nc.ConnectedUrl()),
+				ExpectError: regexp.MustCompile(`consumer "ORDERS" > "MISSING" does not exist`),
+			},
+		},
+	})
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/jsm.go"
+)
+
+func dataSourceConsumers() *schema.Resource {
+	summary := consumerStateSchema()
+	summary["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The consumer id",
+		Computed:    true,
+	}
+	summary["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The name of the consumer",
+		Computed:    true,
+	}
+	summary["durable_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The durable name of the consumer, empty for ephemeral consumers",
+		Computed:    true,
+	}
+	summary["description"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The description of the consumer",
+		Computed:    true,
+	}
+	summary["delivery_subject"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The subject push consumers deliver messages to, empty for pull consumers",
+		Computed:    true,
+	}
+	summary["filter_subjects"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The subjects the consumer filters on, empty when consuming the whole stream",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	summary["metadata"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "The consumer metadata",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceConsumersRead,
+
+		Schema: map[string]*schema.Schema{
+			"stream": {
+				Type:         schema.TypeString,
+				Description:  "The name of the stream to list consumers for",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"filter_subject": {
+				Type:        schema.TypeString,
+				Description: "Only include consumers with a filter subject overlapping this subject, wildcards are supported",
+				Optional:    true,
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Description: "Only include consumers with all these metadata keys and values",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Description: "The sorted names of the matching consumers",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"consumers": {
+				Type:        schema.TypeList,
+				Description: "A summary of each matching consumer, sorted by name",
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: summary},
+			},
+		},
+	}
+}
+
+type consumerList struct {
+	consumers []*jsm.Consumer
+	missing   []string
+	offline   map[string]string
+}
+
+func dataSourceConsumersRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	stream := d.Get("stream").(string)
+	filter := d.Get("filter_subject").(string)
+	metadata := d.Get("metadata").(map[string]any)
+
+	mgr, err := getManager(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	known, err := retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownStream(stream) })
+	if err != nil {
+		return diag.Errorf("could not determine if stream %q is known: %s", stream, err)
+	}
+	if !known {
+		return diag.Errorf("stream %q does not exist", stream)
+	}
+
+	list, err := retryValue(ctx, func(context.Context) (consumerList, error) {
+		consumers, missing, offline, err := mgr.Consumers(stream)
+		return consumerList{consumers, missing, offline}, err
+	})
+	if err != nil {
+		return diag.Errorf("could not list consumers of stream %q: %s", stream, err)
+	}
+
+	var names []string
+	var summaries []map[string]any
+	for _, cons := range list.consumers {
+		subjects := consumerFilterSubjects(cons)
+		if filter != "" && !anySubjectOverlaps(subjects, filter) {
+			continue
+		}
+
+		if !hasMetadata(cons.Metadata(), metadata) {
+			continue
+		}
+
+		nfo, err := cons.LatestState()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		summary := consumerStateData(nfo)
+		summary["id"] = consumerID(stream, cons.Name())
+		summary["name"] = cons.Name()
+		summary["durable_name"] = cons.DurableName()
+		summary["description"] = cons.Description()
+		summary["delivery_subject"] = cons.DeliverySubject()
+		summary["filter_subjects"] = subjects
+		summary["metadata"] = jsm.FilterServerMetadata(cons.Metadata())
+
+		names = append(names, cons.Name())
+		summaries = append(summaries, summary)
+	}
+
+	d.Set("names", names)
+	d.Set("consumers", summaries)
+	d.SetId(fmt.Sprintf("%d", schema.HashString(fmt.Sprintf("%s|%s|%v", stream, filter, metadata))))
+
+	var diags diag.Diagnostics
+	unavailable := list.missing
+	for name := range list.offline {
+		unavailable = append(unavailable, name)
+	}
+	if len(unavailable) > 0 {
+		sort.Strings(unavailable)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Some consumers could not be listed",
+			Detail:   fmt.Sprintf("The consumers %s of stream %q did not respond or are offline and were not included", strings.Join(unavailable, ", "), stream),
+		})
+	}
+
+	return diags
+}
+
+func consumerFilterSubjects(cons *jsm.Consumer) []string {
+	if cons.FilterSubject() != "" {
+		return []string{cons.FilterSubject()}
+	}
+
+	return cons.FilterSubjects()
+}
+
+// anySubjectOverlaps determines if any of subjects overlaps filter, no subjects means the whole stream is consumed
+func anySubjectOverlaps(subjects []string, filter string) bool {
+	if len(subjects) == 0 {
+		return true
+	}
+
+	for _, s := range subjects {
+		if subjectsOverlap(s, filter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subjectsOverlap determines if any subject could match both a and b, either may contain wildcards
+func subjectsOverlap(a, b string) bool {
+	at := strings.Split(a, ".")
+	bt := strings.Split(b, ".")
+
+	for i := 0; i < len(at) && i < len(bt); i++ {
+		switch {
+		case at[i] == ">" || bt[i] == ">":
+			return true
+		case at[i] == "*" || bt[i] == "*" || at[i] == bt[i]:
+			continue
+		default:
+			return false
+		}
+	}
+
+	return len(at) == len(bt)
+}
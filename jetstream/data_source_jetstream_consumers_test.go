@@ -0,0 +1,114 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats.go"
+)
+
+const testDataSourceConsumers = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_consumers" "all" {
+  stream = "ORDERS"
+}
+
+data "jetstream_consumers" "subject" {
+  stream         = "ORDERS"
+  filter_subject = "ORDERS.eu.*"
+}
+
+data "jetstream_consumers" "metadata" {
+  stream = "ORDERS"
+
+  metadata = {
+    team = "shipping"
+  }
+}
+`
+
+func TestSubjectsOverlap(t *testing.T) {
+	cases := []struct {
+		a, b    string
+		overlap bool
+	}{
+		{"ORDERS.new", "ORDERS.new", true},
+		{"ORDERS.new", "ORDERS.old", false},
+		{"ORDERS.*", "ORDERS.new", true},
+		{"ORDERS.new", "ORDERS.>", true},
+		{"ORDERS.*.new", "ORDERS.eu.*", true},
+		{"ORDERS.*", "ORDERS.eu.new", false},
+		{"ORDERS.>", "ORDERS", false},
+		{">", "ORDERS.eu.new", true},
+		{"ORDERS.eu", "ORDERS.eu.new", false},
+	}
+
+	for _, c := range cases {
+		if subjectsOverlap(c.a, c.b) != c.overlap {
+			t.Fatalf("expected overlap of %q and %q to be %v", c.a, c.b, c.overlap)
+		}
+		if subjectsOverlap(c.b, c.a) != c.overlap {
+			t.Fatalf("expected overlap of %q and %q to be %v", c.b, c.a, c.overlap)
+		}
+	}
+}
+
+func TestDataSourceConsumers(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	checkErr(t, err, "could not create manager: %v", err)
+
+	_, err = mgr.NewStream("ORDERS", jsm.Subjects("ORDERS.>"))
+	checkErr(t, err, "could not create stream: %v", err)
+
+	_, err = mgr.NewConsumer("ORDERS", jsm.DurableName("EU"), jsm.FilterStreamBySubject("ORDERS.eu.new"), jsm.MaxRequestBatch(1))
+	checkErr(t, err, "could not create consumer: %v", err)
+	_, err = mgr.NewConsumer("ORDERS", jsm.DurableName("US"), jsm.FilterStreamBySubject("ORDERS.us.new"), jsm.MaxRequestBatch(1), jsm.ConsumerMetadata(map[string]string{"team": "shipping"}))
+	checkErr(t, err, "could not create consumer: %v", err)
+	_, err = mgr.NewConsumer("ORDERS", jsm.DurableName("ALL"), jsm.MaxRequestBatch(1))
+	checkErr(t, err, "could not create consumer: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceConsumers, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetstream_consumers.all", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.jetstream_consumers.all", "names.0", "ALL"),
+					resource.TestCheckResourceAttr("data.jetstream_consumers.subject", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.jetstream_consumers.subject", "names.0", "ALL"),
+					resource.TestCheckResourceAttr("data.jetstream_consumers.subject", "names.1", "EU"),
+					resource.TestCheckResourceAttr("data.jetstream_consumers.subject", "consumers.1.filter_subjects.0", "ORDERS.eu.new"),
+					resource.TestCheckResourceAttr("data.jetstream_consumers.metadata", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_consumers.metadata", "consumers.0.id", "ORDERS/US"),
+					resource.TestCheckResourceAttr("data.jetstream_consumers.metadata", "consumers.0.num_pending", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nats-io/jsm.go"
+)
+
+func dataSourceStream() *schema.Resource {
+	s := dataSourceSchemaFromResource(resourceStream().Schema, []string{"name"}, "wait_for_healthy", "healthy_max_lag")
+
+	s["messages"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The number of messages stored in the stream",
+		Computed:    true,
+	}
+	s["bytes"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The number of bytes stored in the stream",
+		Computed:    true,
+	}
+	s["first_sequence"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The sequence of the first message in the stream",
+		Computed:    true,
+	}
+	s["last_sequence"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The sequence of the last message in the stream",
+		Computed:    true,
+	}
+	s["consumer_count"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The number of consumers on the stream",
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceStreamRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceStreamRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	name := d.Get("name").(string)
+
+	mgr, err := getManager(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	known, err := retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownStream(name) })
+	if err != nil {
+		return diag.Errorf("could not determine if stream %q is known: %s", name, err)
+	}
+	if !known {
+		return diag.Errorf("stream %q does not exist", name)
+	}
+
+	str, err := retryValue(ctx, func(context.Context) (*jsm.Stream, error) { return mgr.LoadStream(name) })
+	if err != nil {
+		return diag.Errorf("could not load stream %q: %s", name, err)
+	}
+
+	err = setStreamResourceData(d, str)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nfo, err := str.LatestInformation()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("messages", int(nfo.State.Msgs))
+	d.Set("bytes", int(nfo.State.Bytes))
+	d.Set("first_sequence", int(nfo.State.FirstSeq))
+	d.Set("last_sequence", int(nfo.State.LastSeq))
+	d.Set("consumer_count", nfo.State.Consumers)
+
+	// matches the resource id so it can be used as stream_id on consumers
+	d.SetId(streamID(name))
+
+	return nil
+}
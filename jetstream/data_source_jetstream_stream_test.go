@@ -0,0 +1,106 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats.go"
+)
+
+const testDataSourceStream = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_stream" "test" {
+  name = "ORDERS"
+}
+
+resource "jetstream_consumer" "test" {
+  stream_id    = data.jetstream_stream.test.id
+  durable_name = "NEW"
+  deliver_all  = true
+  max_batch    = 1
+}
+`
+
+const testDataSourceStreamMissing = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_stream" "test" {
+  name = "MISSING"
+}
+`
+
+func TestDataSourceStream(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+
+	_, err = mgr.NewStream("ORDERS", jsm.Subjects("ORDERS.*"), jsm.MaxAge(time.Hour), jsm.StreamMetadata(map[string]string{"team": "orders"}))
+	if err != nil {
+		t.Fatalf("could not create stream: %s", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		_, err = nc.Request("ORDERS.new", []byte("order"), time.Second)
+		if err != nil {
+			t.Fatalf("could not publish: %s", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testConsumerDoesNotExist(t, mgr, "ORDERS", "NEW"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceStream, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					testConsumerExist(t, mgr, "ORDERS", "NEW"),
+					resource.TestCheckResourceAttr("data.jetstream_stream.test", "name", "ORDERS"),
+					resource.TestCheckResourceAttr("data.jetstream_stream.test", "subjects.0", "ORDERS.*"),
+					resource.TestCheckResourceAttr("data.jetstream_stream.test", "max_age", "3600"),
+					resource.TestCheckResourceAttr("data.jetstream_stream.test", "metadata.team", "orders"),
+					resource.TestCheckResourceAttr("data.jetstream_stream.test", "storage", "file"),
+					resource.TestCheckResourceAttr("data.jetstream_stream.test", "messages", "5"),
+					resource.TestCheckResourceAttr("data.jetstream_stream.test", "first_sequence", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_stream.test", "last_sequence", "5"),
+					resource.TestCheckResourceAttrSet("data.jetstream_stream.test", "bytes"),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testDataSourceStreamMissing, nc.ConnectedUrl()),
+				ExpectError: regexp.MustCompile(`stream "MISSING" does not exist`),
+			},
+		},
+	})
+}
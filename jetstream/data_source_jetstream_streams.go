@@ -0,0 +1,273 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/jsm.go"
+)
+
+func dataSourceStreams() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStreamsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Description:  "Only include streams with names matching this regular expression",
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Description: "Only include streams with subjects overlapping this subject, wildcards are supported",
+				Optional:    true,
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Description: "Only include streams with all these metadata keys and values",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Description: "The sorted names of the matching streams",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"streams": {
+				Type:        schema.TypeList,
+				Description: "A summary of each matching stream, sorted by name",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The stream id, usable as stream_id on consumers",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the stream",
+							Computed:    true,
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Description: "The description of the stream",
+							Computed:    true,
+						},
+						"subjects": {
+							Type:        schema.TypeList,
+							Description: "The subjects the stream consumes",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"metadata": {
+							Type:        schema.TypeMap,
+							Description: "The stream metadata",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"storage": {
+							Type:        schema.TypeString,
+							Description: "The storage engine backing the stream",
+							Computed:    true,
+						},
+						"retention": {
+							Type:        schema.TypeString,
+							Description: "The retention policy of the stream",
+							Computed:    true,
+						},
+						"replicas": {
+							Type:        schema.TypeInt,
+							Description: "The number of replicas of the stream",
+							Computed:    true,
+						},
+						"max_age": {
+							Type:        schema.TypeString,
+							Description: "The maximum age of messages in the stream",
+							Computed:    true,
+						},
+						"max_bytes": {
+							Type:        schema.TypeString,
+							Description: "The maximum size of the stream",
+							Computed:    true,
+						},
+						"is_mirror": {
+							Type:        schema.TypeBool,
+							Description: "If the stream is a mirror",
+							Computed:    true,
+						},
+						"is_sourced": {
+							Type:        schema.TypeBool,
+							Description: "If the stream sources other streams",
+							Computed:    true,
+						},
+						"messages": {
+							Type:        schema.TypeInt,
+							Description: "The number of messages stored in the stream",
+							Computed:    true,
+						},
+						"bytes": {
+							Type:        schema.TypeInt,
+							Description: "The number of bytes stored in the stream",
+							Computed:    true,
+						},
+						"first_sequence": {
+							Type:        schema.TypeInt,
+							Description: "The sequence of the first message in the stream",
+							Computed:    true,
+						},
+						"last_sequence": {
+							Type:        schema.TypeInt,
+							Description: "The sequence of the last message in the stream",
+							Computed:    true,
+						},
+						"consumer_count": {
+							Type:        schema.TypeInt,
+							Description: "The number of consumers on the stream",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type streamList struct {
+	streams []*jsm.Stream
+	missing []string
+	offline map[string]string
+}
+
+func dataSourceStreamsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	nameRegex := d.Get("name_regex").(string)
+	subject := d.Get("subject").(string)
+	metadata := d.Get("metadata").(map[string]any)
+
+	var re *regexp.Regexp
+	if nameRegex != "" {
+		var err error
+		re, err = regexp.Compile(nameRegex)
+		if err != nil {
+			return diag.Errorf("invalid name_regex: %s", err)
+		}
+	}
+
+	mgr, err := getManager(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the server filters by subject, name and metadata filters are applied here
+	list, err := retryValue(ctx, func(context.Context) (streamList, error) {
+		streams, missing, offline, err := mgr.Streams(&jsm.StreamNamesFilter{Subject: subject})
+		return streamList{streams, missing, offline}, err
+	})
+	if err != nil {
+		return diag.Errorf("could not list streams: %s", err)
+	}
+
+	var matched []*jsm.Stream
+	for _, str := range list.streams {
+		if re != nil && !re.MatchString(str.Name()) {
+			continue
+		}
+
+		if !hasMetadata(str.Metadata(), metadata) {
+			continue
+		}
+
+		matched = append(matched, str)
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name() < matched[j].Name() })
+
+	names := make([]string, len(matched))
+	summaries := make([]map[string]any, len(matched))
+	for i, str := range matched {
+		names[i] = str.Name()
+		summaries[i] = streamSummary(str)
+	}
+
+	d.Set("names", names)
+	d.Set("streams", summaries)
+	d.SetId(fmt.Sprintf("%d", schema.HashString(fmt.Sprintf("%s|%s|%v", nameRegex, subject, metadata))))
+
+	var diags diag.Diagnostics
+	unavailable := list.missing
+	for name := range list.offline {
+		unavailable = append(unavailable, name)
+	}
+	if len(unavailable) > 0 {
+		sort.Strings(unavailable)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Some streams could not be listed",
+			Detail:   fmt.Sprintf("The streams %s did not respond or are offline and were not included", strings.Join(unavailable, ", ")),
+		})
+	}
+
+	return diags
+}
+
+// hasMetadata determines if meta has all the keys and values in want
+func hasMetadata(meta map[string]string, want map[string]any) bool {
+	for k, v := range want {
+		val, ok := meta[k]
+		if !ok || val != v.(string) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func streamSummary(str *jsm.Stream) map[string]any {
+	summary := map[string]any{
+		"id":          streamID(str.Name()),
+		"name":        str.Name(),
+		"description": str.Description(),
+		"subjects":    str.Subjects(),
+		"metadata":    jsm.FilterServerMetadata(str.Metadata()),
+		"replicas":    str.Replicas(),
+		"max_age":     formatDuration(str.MaxAge()),
+		"max_bytes":   formatByteSize(str.MaxBytes()),
+		"is_mirror":   str.IsMirror(),
+		"is_sourced":  str.IsSourced(),
+		"storage":     strings.ToLower(str.Storage().String()),
+		"retention":   strings.ToLower(str.Retention().String()),
+	}
+
+	// Streams() returns streams with their information already loaded
+	nfo, err := str.LatestInformation()
+	if err == nil {
+		summary["messages"] = int(nfo.State.Msgs)
+		summary["bytes"] = int(nfo.State.Bytes)
+		summary["first_sequence"] = int(nfo.State.FirstSeq)
+		summary["last_sequence"] = int(nfo.State.LastSeq)
+		summary["consumer_count"] = nfo.State.Consumers
+	}
+
+	return summary
+}
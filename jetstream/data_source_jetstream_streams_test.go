@@ -0,0 +1,98 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats.go"
+)
+
+const testDataSourceStreams = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_streams" "all" {
+}
+
+data "jetstream_streams" "regex" {
+  name_regex = "^ORDERS_"
+}
+
+data "jetstream_streams" "subject" {
+  subject = "ORDERS.eu.>"
+}
+
+data "jetstream_streams" "metadata" {
+  name_regex = "^ORDERS_"
+
+  metadata = {
+    team = "orders"
+  }
+}
+`
+
+func TestDataSourceStreams(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	checkErr(t, err, "could not create manager: %v", err)
+
+	_, err = mgr.NewStream("ORDERS_EU", jsm.Subjects("ORDERS.eu.*"), jsm.MaxAge(time.Hour), jsm.StreamMetadata(map[string]string{"team": "orders"}))
+	checkErr(t, err, "could not create stream: %v", err)
+	_, err = mgr.NewStream("ORDERS_US", jsm.Subjects("ORDERS.us.*"), jsm.StreamMetadata(map[string]string{"team": "shipping"}))
+	checkErr(t, err, "could not create stream: %v", err)
+	_, err = mgr.NewStream("AUDIT", jsm.Subjects("AUDIT.>"))
+	checkErr(t, err, "could not create stream: %v", err)
+
+	for i := 0; i < 3; i++ {
+		_, err = nc.Request("ORDERS.eu.new", []byte("order"), time.Second)
+		checkErr(t, err, "could not publish: %v", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceStreams, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetstream_streams.all", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.all", "names.0", "AUDIT"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.all", "names.1", "ORDERS_EU"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.all", "names.2", "ORDERS_US"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.regex", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.subject", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.subject", "names.0", "ORDERS_EU"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.metadata", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.metadata", "streams.0.id", "ORDERS_EU"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.metadata", "streams.0.subjects.0", "ORDERS.eu.*"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.metadata", "streams.0.max_age", "3600"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.metadata", "streams.0.storage", "file"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.metadata", "streams.0.messages", "3"),
+					resource.TestCheckResourceAttr("data.jetstream_streams.metadata", "streams.0.last_sequence", "3"),
+				),
+			},
+		},
+	})
+}
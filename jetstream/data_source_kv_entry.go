@@ -0,0 +1,196 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func dataSourceKVEntry() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKVEntryRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Description:  "The name of the bucket",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Description:  "The key of the entry",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"revision": {
+				Type:         schema.TypeInt,
+				Description:  "Read this revision of the entry rather than the latest, also set to the revision that was read",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"allow_missing": {
+				Type:        schema.TypeBool,
+				Description: "When the key does not exist or is deleted set exists to false and the value to null rather than failing",
+				Optional:    true,
+				Default:     false,
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Description: "If the key exists and is not deleted",
+				Computed:    true,
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Description: "The value of the entry as a string",
+				Computed:    true,
+			},
+			"value_base64": {
+				Type:        schema.TypeString,
+				Description: "The value of the entry encoded as base64, suitable for binary values",
+				Computed:    true,
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Description: "When the revision was written, in RFC3339 format",
+				Computed:    true,
+			},
+			"operation": {
+				Type:        schema.TypeString,
+				Description: "The operation of the revision, one of PUT, DEL or PURGE",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// kvOperationString renders op in the same way as the nats CLI
+func kvOperationString(op jetstream.KeyValueOp) string {
+	switch op {
+	case jetstream.KeyValuePut:
+		return "PUT"
+	case jetstream.KeyValueDelete:
+		return "DEL"
+	case jetstream.KeyValuePurge:
+		return "PURGE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// kvDeleteMarker finds the delete or purge marker hiding a key, or the requested revision when it is a marker,
+// Get and GetRevision report these as not found
+func kvDeleteMarker(ctx context.Context, kv jetstream.KeyValue, key string, revision uint64) (jetstream.KeyValueEntry, error) {
+	history, err := retryValue(ctx, func(context.Context) ([]jetstream.KeyValueEntry, error) { return kv.History(ctx, key) })
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for i := len(history) - 1; i >= 0; i-- {
+		entry := history[i]
+		if revision > 0 && entry.Revision() != revision {
+			continue
+		}
+		if entry.Operation() != jetstream.KeyValuePut {
+			return entry, nil
+		}
+		break
+	}
+
+	return nil, nil
+}
+
+func dataSourceKVEntryRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	allowMissing := d.Get("allow_missing").(bool)
+
+	var revision uint64
+	if v, ok := d.GetOk("revision"); ok {
+		revision = uint64(v.(int))
+	}
+
+	js, err := getJetStream(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	kv, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, bucket) })
+	if err != nil {
+		if errors.Is(err, jetstream.ErrBucketNotFound) {
+			return diag.Errorf("bucket %q does not exist", bucket)
+		}
+		return diag.FromErr(err)
+	}
+
+	entry, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValueEntry, error) {
+		if revision > 0 {
+			return kv.GetRevision(ctx, key, revision)
+		}
+		return kv.Get(ctx, key)
+	})
+	if err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		return diag.Errorf("could not read key %q from bucket %q: %s", key, bucket, err)
+	}
+
+	d.SetId(kvEntryID(bucket, key))
+
+	if err == nil {
+		d.Set("exists", true)
+		d.Set("value", string(entry.Value()))
+		d.Set("value_base64", base64.StdEncoding.EncodeToString(entry.Value()))
+		d.Set("revision", int(entry.Revision()))
+		d.Set("created", entry.Created().Format(time.RFC3339))
+		d.Set("operation", kvOperationString(entry.Operation()))
+
+		return nil
+	}
+
+	marker, err := kvDeleteMarker(ctx, kv, key, revision)
+	if err != nil {
+		return diag.Errorf("could not read the history of key %q in bucket %q: %s", key, bucket, err)
+	}
+
+	if !allowMissing {
+		if marker != nil {
+			return diag.Errorf("key %q in bucket %q is deleted", key, bucket)
+		}
+		if revision > 0 {
+			return diag.Errorf("revision %d of key %q in bucket %q does not exist", revision, key, bucket)
+		}
+		return diag.Errorf("key %q in bucket %q does not exist", key, bucket)
+	}
+
+	// the value attributes are left unset so they are null
+	d.Set("exists", false)
+
+	if marker != nil {
+		d.Set("revision", int(marker.Revision()))
+		d.Set("created", marker.Created().Format(time.RFC3339))
+		d.Set("operation", kvOperationString(marker.Operation()))
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const testDataSourceKVEntry = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_kv_entry" "latest" {
+  bucket = "CONFIG"
+  key    = "replicas"
+}
+
+data "jetstream_kv_entry" "revision" {
+  bucket   = "CONFIG"
+  key      = "replicas"
+  revision = 1
+}
+
+data "jetstream_kv_entry" "deleted" {
+  bucket        = "CONFIG"
+  key           = "removed"
+  allow_missing = true
+}
+
+data "jetstream_kv_entry" "missing" {
+  bucket        = "CONFIG"
+  key           = "missing"
+  allow_missing = true
+}
+`
+
+const testDataSourceKVEntryDeleted = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_kv_entry" "deleted" {
+  bucket = "CONFIG"
+  key    = "removed"
+}
+`
+
+const testDataSourceKVEntryMissing = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_kv_entry" "missing" {
+  bucket = "CONFIG"
+  key    = "missing"
+}
+`
+
+func TestDataSourceKVEntry(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	kv, err := js.CreateKeyValue(ctx, jetstream.KeyValueConfig{Bucket: "CONFIG", History: 5})
+	checkErr(t, err, "could not create bucket: %v", err)
+
+	_, err = kv.PutString(ctx, "replicas", "1")
+	checkErr(t, err, "could not put: %v", err)
+	_, err = kv.Put(ctx, "replicas", []byte{'3', 0})
+	checkErr(t, err, "could not put: %v", err)
+	_, err = kv.PutString(ctx, "removed", "x")
+	checkErr(t, err, "could not put: %v", err)
+	err = kv.Delete(ctx, "removed")
+	checkErr(t, err, "could not delete: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceKVEntry, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.latest", "exists", "true"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.latest", "value_base64", "MwA="),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.latest", "revision", "2"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.latest", "operation", "PUT"),
+					resource.TestCheckResourceAttrSet("data.jetstream_kv_entry.latest", "created"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.revision", "value", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.revision", "revision", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.deleted", "exists", "false"),
+					resource.TestCheckNoResourceAttr("data.jetstream_kv_entry.deleted", "value"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.deleted", "operation", "DEL"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.deleted", "revision", "4"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.missing", "exists", "false"),
+					resource.TestCheckNoResourceAttr("data.jetstream_kv_entry.missing", "value"),
+					resource.TestCheckNoResourceAttr("data.jetstream_kv_entry.missing", "operation"),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testDataSourceKVEntryDeleted, nc.ConnectedUrl()),
+				ExpectError: regexp.MustCompile(`key "removed" in bucket "CONFIG" is deleted`),
+			},
+			{
+				Config:      fmt.Sprintf(testDataSourceKVEntryMissing, nc.ConnectedUrl()),
+				ExpectError: regexp.MustCompile(`key "missing" in bucket "CONFIG" does not exist`),
+			},
+		},
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func dataSourceKVHistory() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKVHistoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Description:  "The name of the bucket",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Description:  "The key to read the history of",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"entries": {
+				Type:        schema.TypeList,
+				Description: "Every retained revision of the key, oldest first",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"revision": {
+							Type:        schema.TypeInt,
+							Description: "The revision of the entry",
+							Computed:    true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "The value of the entry as a string, empty for deletes and purges",
+							Computed:    true,
+						},
+						"value_base64": {
+							Type:        schema.TypeString,
+							Description: "The value of the entry encoded as base64",
+							Computed:    true,
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Description: "When the revision was written, in RFC3339 format",
+							Computed:    true,
+						},
+						"operation": {
+							Type:        schema.TypeString,
+							Description: "The operation of the revision, one of PUT, DEL or PURGE",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKVHistoryRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+
+	js, err := getJetStream(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	kv, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, bucket) })
+	if err != nil {
+		if errors.Is(err, jetstream.ErrBucketNotFound) {
+			return diag.Errorf("bucket %q does not exist", bucket)
+		}
+		return diag.FromErr(err)
+	}
+
+	history, err := retryValue(ctx, func(context.Context) ([]jetstream.KeyValueEntry, error) { return kv.History(ctx, key) })
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return diag.Errorf("key %q in bucket %q does not exist", key, bucket)
+		}
+		return diag.Errorf("could not read the history of key %q in bucket %q: %s", key, bucket, err)
+	}
+
+	entries := make([]map[string]any, len(history))
+	for i, entry := range history {
+		entries[i] = map[string]any{
+			"revision":     int(entry.Revision()),
+			"value":        string(entry.Value()),
+			"value_base64": base64.StdEncoding.EncodeToString(entry.Value()),
+			"created":      entry.Created().Format(time.RFC3339),
+			"operation":    kvOperationString(entry.Operation()),
+		}
+	}
+
+	d.Set("entries", entries)
+	d.SetId(kvEntryID(bucket, key))
+
+	return nil
+}
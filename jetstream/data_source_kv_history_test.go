@@ -0,0 +1,99 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const testDataSourceKVHistory = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_kv_history" "test" {
+  bucket = "CONFIG"
+  key    = "replicas"
+}
+`
+
+const testDataSourceKVHistoryMissing = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_kv_history" "test" {
+  bucket = "CONFIG"
+  key    = "missing"
+}
+`
+
+func TestDataSourceKVHistory(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	kv, err := js.CreateKeyValue(ctx, jetstream.KeyValueConfig{Bucket: "CONFIG", History: 10})
+	checkErr(t, err, "could not create bucket: %v", err)
+
+	_, err = kv.PutString(ctx, "replicas", "1")
+	checkErr(t, err, "could not put: %v", err)
+	_, err = kv.PutString(ctx, "other", "x")
+	checkErr(t, err, "could not put: %v", err)
+	_, err = kv.PutString(ctx, "replicas", "3")
+	checkErr(t, err, "could not put: %v", err)
+	err = kv.Delete(ctx, "replicas")
+	checkErr(t, err, "could not delete: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceKVHistory, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetstream_kv_history.test", "entries.#", "3"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_history.test", "entries.0.revision", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_history.test", "entries.0.value", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_history.test", "entries.0.operation", "PUT"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_history.test", "entries.1.revision", "3"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_history.test", "entries.1.value_base64", "Mw=="),
+					resource.TestCheckResourceAttr("data.jetstream_kv_history.test", "entries.2.revision", "4"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_history.test", "entries.2.operation", "DEL"),
+					resource.TestCheckResourceAttrSet("data.jetstream_kv_history.test", "entries.2.created"),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testDataSourceKVHistoryMissing, nc.ConnectedUrl()),
+				ExpectError: regexp.MustCompile(`key "missing" in bucket "CONFIG" does not exist`),
+			},
+		},
+	})
+}
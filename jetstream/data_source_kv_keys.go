@@ -0,0 +1,102 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func dataSourceKVKeys() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKVKeysRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Description:  "The name of the bucket",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"filter": {
+				Type:         schema.TypeString,
+				Description:  "Only include keys matching this filter, using subject wildcards like services.*.config",
+				Optional:     true,
+				Default:      ">",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"keys": {
+				Type:        schema.TypeList,
+				Description: "The sorted keys that are not deleted",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// listKVKeys lists the keys matching filter that are not deleted
+func listKVKeys(ctx context.Context, kv jetstream.KeyValue, filter string) ([]string, error) {
+	lister, err := kv.ListKeysFiltered(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for key := range lister.Keys() {
+		keys = append(keys, key)
+	}
+
+	// the lister stops early without error when the context is done
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func dataSourceKVKeysRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	filter := d.Get("filter").(string)
+
+	js, err := getJetStream(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	kv, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, bucket) })
+	if err != nil {
+		if errors.Is(err, jetstream.ErrBucketNotFound) {
+			return diag.Errorf("bucket %q does not exist", bucket)
+		}
+		return diag.FromErr(err)
+	}
+
+	keys, err := retryValue(ctx, func(ctx context.Context) ([]string, error) { return listKVKeys(ctx, kv, filter) })
+	if err != nil {
+		return diag.Errorf("could not list keys in bucket %q: %s", bucket, err)
+	}
+
+	d.Set("keys", keys)
+	d.SetId(joinID(bucket, filter))
+
+	return nil
+}
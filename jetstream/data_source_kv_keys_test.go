@@ -0,0 +1,89 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const testDataSourceKVKeys = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_kv_keys" "all" {
+  bucket = "CONFIG"
+}
+
+data "jetstream_kv_keys" "configs" {
+  bucket = "CONFIG"
+  filter = "services.*.config"
+}
+
+data "jetstream_kv_entry" "configs" {
+  count = length(data.jetstream_kv_keys.configs.keys)
+
+  bucket = "CONFIG"
+  key    = data.jetstream_kv_keys.configs.keys[count.index]
+}
+`
+
+func TestDataSourceKVKeys(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	kv, err := js.CreateKeyValue(ctx, jetstream.KeyValueConfig{Bucket: "CONFIG"})
+	checkErr(t, err, "could not create bucket: %v", err)
+
+	for _, key := range []string{"services.web.config", "services.api.config", "services.api.secret", "global", "removed"} {
+		_, err = kv.PutString(ctx, key, key)
+		checkErr(t, err, "could not put: %v", err)
+	}
+	err = kv.Delete(ctx, "removed")
+	checkErr(t, err, "could not delete: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceKVKeys, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetstream_kv_keys.all", "keys.#", "4"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_keys.all", "keys.0", "global"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_keys.configs", "keys.#", "2"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_keys.configs", "keys.0", "services.api.config"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_keys.configs", "keys.1", "services.web.config"),
+					resource.TestCheckResourceAttr("data.jetstream_kv_entry.configs.1", "value", "services.web.config"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// objectInfoSchema describes the information stored about an object
+func objectInfoSchema() map[string]*schema.Schema {
+	computed := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{Type: t, Description: description, Computed: true}
+	}
+
+	return map[string]*schema.Schema{
+		"description": computed(schema.TypeString, "Contains additional information about this object"),
+		"headers": {
+			Type:        schema.TypeMap,
+			Description: "Headers stored with the object",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"metadata": {
+			Type:        schema.TypeMap,
+			Description: "Free form metadata about the object",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"size":        computed(schema.TypeInt, "The size of the object in bytes"),
+		"chunks":      computed(schema.TypeInt, "The number of chunks the object is stored in"),
+		"digest":      computed(schema.TypeString, "The SHA-256 digest of the object"),
+		"modified":    computed(schema.TypeString, "When the object was last modified, in RFC3339 format"),
+		"is_link":     computed(schema.TypeBool, "If the object is a link to another object or bucket"),
+		"link_bucket": computed(schema.TypeString, "The bucket the link points to"),
+		"link_object": computed(schema.TypeString, "The object the link points to, empty for links to a whole bucket"),
+	}
+}
+
+func objectInfoData(info *jetstream.ObjectInfo) map[string]any {
+	data := map[string]any{
+		"description": info.Description,
+		"headers":     objHeadersMap(info.Headers),
+		"metadata":    info.Metadata,
+		"size":        int(info.Size),
+		"chunks":      int(info.Chunks),
+		"digest":      info.Digest,
+		"modified":    info.ModTime.Format(time.RFC3339),
+		"is_link":     false,
+		"link_bucket": "",
+		"link_object": "",
+	}
+
+	if info.Opts != nil && info.Opts.Link != nil {
+		data["is_link"] = true
+		data["link_bucket"] = info.Opts.Link.Bucket
+		data["link_object"] = info.Opts.Link.Name
+	}
+
+	return data
+}
+
+func dataSourceObjObject() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"bucket": {
+			Type:         schema.TypeString,
+			Description:  "The name of the Object Store bucket",
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Description:  "The name of the object",
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"include_content": {
+			Type:        schema.TypeBool,
+			Description: "Reads the content of the object into content and content_base64",
+			Optional:    true,
+			Default:     false,
+		},
+		"max_content_size": {
+			Type:             schema.TypeString,
+			Description:      "The largest object that can be read when using include_content, as a number of bytes or a size like 1MiB",
+			Optional:         true,
+			Default:          "1MiB",
+			ValidateDiagFunc: validateByteSize(),
+		},
+		"content": {
+			Type:        schema.TypeString,
+			Description: "The content of the object as a string",
+			Computed:    true,
+		},
+		"content_base64": {
+			Type:        schema.TypeString,
+			Description: "The content of the object encoded as base64, suitable for binary content",
+			Computed:    true,
+		},
+	}
+
+	for k, v := range objectInfoSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceObjObjectRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceObjObjectRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	name := d.Get("name").(string)
+
+	obj, err := objStore(ctx, m, bucket)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrBucketNotFound) {
+			return diag.Errorf("bucket %q does not exist", bucket)
+		}
+		return diag.FromErr(err)
+	}
+
+	info, err := retryValue(ctx, func(ctx context.Context) (*jetstream.ObjectInfo, error) { return obj.GetInfo(ctx, name) })
+	if err != nil {
+		if errors.Is(err, jetstream.ErrObjectNotFound) {
+			return diag.Errorf("object %q in bucket %q does not exist", name, bucket)
+		}
+		return diag.FromErr(err)
+	}
+
+	for k, v := range objectInfoData(info) {
+		d.Set(k, v)
+	}
+
+	if d.Get("include_content").(bool) {
+		// links are read as the content of the object they point to
+		size := info.Size
+		if info.Opts != nil && info.Opts.Link != nil {
+			link := info.Opts.Link
+			if link.Name == "" {
+				return diag.Errorf("object %q in bucket %q is a link to bucket %q and has no content", name, bucket, link.Bucket)
+			}
+
+			target, err := objStore(ctx, m, link.Bucket)
+			if err != nil {
+				return diag.Errorf("could not load linked bucket %q: %s", link.Bucket, err)
+			}
+			targetInfo, err := retryValue(ctx, func(ctx context.Context) (*jetstream.ObjectInfo, error) { return target.GetInfo(ctx, link.Name) })
+			if err != nil {
+				return diag.Errorf("could not load linked object %q: %s", link.Name, err)
+			}
+			size = targetInfo.Size
+		}
+
+		maxSize := getByteSize(d, "max_content_size")
+		if maxSize >= 0 && int64(size) > maxSize {
+			return diag.Errorf("object %q in bucket %q is %d bytes which is larger than max_content_size", name, bucket, size)
+		}
+
+		content, err := retryValue(ctx, func(context.Context) ([]byte, error) { return obj.GetBytes(ctx, name) })
+		if err != nil {
+			return diag.Errorf("could not read object %q: %s", name, err)
+		}
+
+		d.Set("content", string(content))
+		d.Set("content_base64", base64.StdEncoding.EncodeToString(content))
+	}
+
+	d.SetId(joinID(bucket, name))
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const testDataSourceObjObject = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_obj_object" "model" {
+  bucket          = "MODELS"
+  name            = "models/v1.bin"
+  include_content = true
+}
+
+data "jetstream_obj_object" "info" {
+  bucket = "MODELS"
+  name   = "models/v1.bin"
+}
+
+data "jetstream_obj_object" "link" {
+  bucket          = "MODELS"
+  name            = "latest"
+  include_content = true
+}
+`
+
+const testDataSourceObjObject_tooLarge = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_obj_object" "model" {
+  bucket           = "MODELS"
+  name             = "models/v1.bin"
+  include_content  = true
+  max_content_size = "4"
+}
+`
+
+func TestDataSourceObjObject(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	obj, err := js.CreateObjectStore(ctx, jetstream.ObjectStoreConfig{Bucket: "MODELS"})
+	checkErr(t, err, "could not create bucket: %v", err)
+
+	info, err := obj.Put(ctx, jetstream.ObjectMeta{
+		Name:        "models/v1.bin",
+		Description: "first model",
+		Headers:     nats.Header{"Content-Type": []string{"text/plain"}},
+		Metadata:    map[string]string{"version": "1"},
+	}, strings.NewReader("model one"))
+	checkErr(t, err, "could not put: %v", err)
+
+	_, err = obj.AddLink(ctx, "latest", info)
+	checkErr(t, err, "could not link: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceObjObject, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.model", "content", "model one"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.model", "content_base64", "bW9kZWwgb25l"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.model", "description", "first model"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.model", "size", "9"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.model", "chunks", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.model", "digest", info.Digest),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.model", "headers.Content-Type", "text/plain"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.model", "metadata.version", "1"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.model", "is_link", "false"),
+					resource.TestCheckResourceAttrSet("data.jetstream_obj_object.model", "modified"),
+					resource.TestCheckNoResourceAttr("data.jetstream_obj_object.info", "content"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.link", "is_link", "true"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.link", "link_bucket", "MODELS"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.link", "link_object", "models/v1.bin"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_object.link", "content", "model one"),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testDataSourceObjObject_tooLarge, nc.ConnectedUrl()),
+				ExpectError: regexp.MustCompile(`is 9 bytes which is larger than max_content_size`),
+			},
+		},
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func dataSourceObjObjects() *schema.Resource {
+	summary := objectInfoSchema()
+	summary["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The name of the object",
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceObjObjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Description:  "The name of the Object Store bucket",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "Only include objects with names starting with this prefix",
+				Optional:    true,
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Description: "The sorted names of the matching objects",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"objects": {
+				Type:        schema.TypeList,
+				Description: "Information about each matching object, sorted by name",
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: summary},
+			},
+		},
+	}
+}
+
+func dataSourceObjObjectsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+
+	obj, err := objStore(ctx, m, bucket)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrBucketNotFound) {
+			return diag.Errorf("bucket %q does not exist", bucket)
+		}
+		return diag.FromErr(err)
+	}
+
+	infos, err := retryValue(ctx, func(context.Context) ([]*jetstream.ObjectInfo, error) { return obj.List(ctx) })
+	if err != nil && !errors.Is(err, jetstream.ErrNoObjectsFound) {
+		return diag.Errorf("could not list objects: %s", err)
+	}
+
+	var matched []*jetstream.ObjectInfo
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name, prefix) {
+			matched = append(matched, info)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+
+	names := make([]string, len(matched))
+	objects := make([]map[string]any, len(matched))
+	for i, info := range matched {
+		names[i] = info.Name
+		objects[i] = objectInfoData(info)
+		objects[i]["name"] = info.Name
+	}
+
+	d.Set("names", names)
+	d.Set("objects", objects)
+
+	if prefix == "" {
+		d.SetId(joinID(bucket))
+	} else {
+		d.SetId(joinID(bucket, prefix))
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const testDataSourceObjObjects = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+data "jetstream_obj_objects" "all" {
+  bucket = "ASSETS"
+}
+
+data "jetstream_obj_objects" "css" {
+  bucket = "ASSETS"
+  prefix = "css/"
+}
+
+data "jetstream_obj_objects" "empty" {
+  bucket = "EMPTY"
+}
+`
+
+func TestDataSourceObjObjects(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	obj, err := js.CreateObjectStore(ctx, jetstream.ObjectStoreConfig{Bucket: "ASSETS"})
+	checkErr(t, err, "could not create bucket: %v", err)
+	_, err = js.CreateObjectStore(ctx, jetstream.ObjectStoreConfig{Bucket: "EMPTY"})
+	checkErr(t, err, "could not create bucket: %v", err)
+
+	for _, name := range []string{"index.html", "css/site.css", "css/print.css", "removed"} {
+		_, err = obj.PutString(ctx, name, name)
+		checkErr(t, err, "could not put: %v", err)
+	}
+	err = obj.Delete(ctx, "removed")
+	checkErr(t, err, "could not delete: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testDataSourceObjObjects, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jetstream_obj_objects.all", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_objects.all", "names.0", "css/print.css"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_objects.css", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_objects.css", "objects.1.name", "css/site.css"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_objects.css", "objects.1.size", "12"),
+					resource.TestCheckResourceAttr("data.jetstream_obj_objects.empty", "names.#", "0"),
+				),
+			},
+		},
+	})
+}
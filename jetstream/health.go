@@ -0,0 +1,146 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+)
+
+const healthPollInterval = time.Second
+
+// clusterHealthy checks that a leader is elected and that all replicas are online and current with
+// at most maxLag operations outstanding, the reason is used in diagnostics when it is not
+func clusterHealthy(ci *api.ClusterInfo, replicas int, maxLag uint64) (healthy bool, reason string) {
+	// not clustered
+	if ci == nil {
+		return true, ""
+	}
+
+	if ci.Leader == "" {
+		return false, "no leader is elected"
+	}
+
+	// the leader is not included in the replica list
+	if replicas > 1 && len(ci.Replicas) < replicas-1 {
+		return false, fmt.Sprintf("%d of %d replicas are known", len(ci.Replicas)+1, replicas)
+	}
+
+	for _, peer := range ci.Replicas {
+		switch {
+		case peer.Offline:
+			return false, fmt.Sprintf("replica %s is offline", peer.Name)
+		case !peer.Current:
+			return false, fmt.Sprintf("replica %s is not current", peer.Name)
+		case peer.Lag > maxLag:
+			return false, fmt.Sprintf("replica %s is lagging by %d operations", peer.Name, peer.Lag)
+		}
+	}
+
+	return true, ""
+}
+
+// waitForHealthy polls check until it reports healthy or ctx is done
+func waitForHealthy(ctx context.Context, what string, check func() (bool, string, error)) error {
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+
+	reason := "health was not checked"
+
+	for {
+		healthy, r, err := check()
+		switch {
+		case err == nil && healthy:
+			return nil
+		case err != nil:
+			reason = err.Error()
+		default:
+			reason = r
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s did not become healthy before the timeout: %s", what, reason)
+		case <-ticker.C:
+		}
+	}
+}
+
+func waitForStreamHealthy(ctx context.Context, mgr *jsm.Manager, stream string, maxLag uint64) error {
+	return waitForHealthy(ctx, fmt.Sprintf("stream %q", stream), func() (bool, string, error) {
+		str, err := mgr.LoadStream(stream)
+		if err != nil {
+			return false, "", err
+		}
+
+		nfo, err := str.Information()
+		if err != nil {
+			return false, "", err
+		}
+
+		healthy, reason := clusterHealthy(nfo.Cluster, nfo.Config.Replicas, maxLag)
+		return healthy, reason, nil
+	})
+}
+
+func waitForConsumerHealthy(ctx context.Context, mgr *jsm.Manager, stream string, consumer string, maxLag uint64) error {
+	return waitForHealthy(ctx, fmt.Sprintf("consumer %q on stream %q", consumer, stream), func() (bool, string, error) {
+		cons, err := mgr.LoadConsumer(stream, consumer)
+		if err != nil {
+			return false, "", err
+		}
+
+		nfo, err := cons.State()
+		if err != nil {
+			return false, "", err
+		}
+
+		str, err := mgr.LoadStream(stream)
+		if err != nil {
+			return false, "", err
+		}
+
+		healthy, reason := clusterHealthy(nfo.Cluster, consumerReplicas(nfo.Config.Replicas, str.Replicas()), maxLag)
+		return healthy, reason, nil
+	})
+}
+
+// consumerReplicas is the effective replica count of a consumer, the server stores 0 for consumers
+// that inherit the replica count of their stream and caps it at the stream replicas
+func consumerReplicas(consumer int, stream int) int {
+	if consumer == 0 || consumer > stream {
+		return stream
+	}
+
+	return consumer
+}
+
+// waitForResourceHealthy waits for the stream backing a resource when wait_for_healthy is set
+func waitForResourceHealthy(ctx context.Context, d *schema.ResourceData, m any, stream string) error {
+	if !d.Get("wait_for_healthy").(bool) {
+		return nil
+	}
+
+	mgr, err := getManager(m)
+	if err != nil {
+		return err
+	}
+
+	return waitForStreamHealthy(ctx, mgr, stream, uint64(d.Get("healthy_max_lag").(int)))
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+)
+
+func TestClusterHealthy(t *testing.T) {
+	peers := func(lag uint64, current bool, offline bool) []*api.PeerInfo {
+		return []*api.PeerInfo{
+			{Name: "n2", Current: true},
+			{Name: "n3", Current: current, Offline: offline, Lag: lag},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		ci       *api.ClusterInfo
+		replicas int
+		maxLag   uint64
+		healthy  bool
+		reason   string
+	}{
+		{name: "not clustered", ci: nil, replicas: 1, healthy: true},
+		{name: "single replica", ci: &api.ClusterInfo{Leader: "n1"}, replicas: 1, healthy: true},
+		{name: "no leader", ci: &api.ClusterInfo{Replicas: peers(0, true, false)}, replicas: 3, reason: "no leader"},
+		{name: "missing replicas", ci: &api.ClusterInfo{Leader: "n1", Replicas: peers(0, true, false)[:1]}, replicas: 3, reason: "2 of 3 replicas"},
+		{name: "offline replica", ci: &api.ClusterInfo{Leader: "n1", Replicas: peers(0, true, true)}, replicas: 3, reason: "n3 is offline"},
+		{name: "replica not current", ci: &api.ClusterInfo{Leader: "n1", Replicas: peers(0, false, false)}, replicas: 3, reason: "n3 is not current"},
+		{name: "lagging replica", ci: &api.ClusterInfo{Leader: "n1", Replicas: peers(10, true, false)}, replicas: 3, maxLag: 5, reason: "lagging by 10"},
+		{name: "lag within threshold", ci: &api.ClusterInfo{Leader: "n1", Replicas: peers(10, true, false)}, replicas: 3, maxLag: 10, healthy: true},
+		{name: "healthy", ci: &api.ClusterInfo{Leader: "n1", Replicas: peers(0, true, false)}, replicas: 3, healthy: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			healthy, reason := clusterHealthy(c.ci, c.replicas, c.maxLag)
+			if healthy != c.healthy {
+				t.Fatalf("expected healthy %v got %v: %s", c.healthy, healthy, reason)
+			}
+			if !strings.Contains(reason, c.reason) {
+				t.Fatalf("expected reason %q to contain %q", reason, c.reason)
+			}
+		})
+	}
+}
+
+func TestConsumerReplicas(t *testing.T) {
+	cases := []struct {
+		consumer int
+		stream   int
+		expected int
+	}{
+		{consumer: 0, stream: 3, expected: 3},
+		{consumer: 1, stream: 3, expected: 1},
+		{consumer: 3, stream: 3, expected: 3},
+		{consumer: 5, stream: 3, expected: 3},
+		{consumer: 0, stream: 1, expected: 1},
+	}
+
+	for _, c := range cases {
+		if r := consumerReplicas(c.consumer, c.stream); r != c.expected {
+			t.Fatalf("expected %d replicas for consumer replicas %d on a stream with %d, got %d", c.expected, c.consumer, c.stream, r)
+		}
+	}
+
+	// an inherited replica count must still require all stream replicas to be known
+	healthy, _ := clusterHealthy(&api.ClusterInfo{Leader: "n1"}, consumerReplicas(0, 3), 0)
+	if healthy {
+		t.Fatalf("expected a consumer inheriting 3 replicas with unknown peers to be unhealthy")
+	}
+}
+
+func TestWaitForStreamHealthy(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	checkErr(t, err, "could not create manager: %v", err)
+
+	_, err = mgr.NewStream("TEST", jsm.Subjects("TEST.*"), jsm.MemoryStorage())
+	checkErr(t, err, "could not create stream: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = waitForStreamHealthy(ctx, mgr, "TEST", 0)
+	checkErr(t, err, "stream did not become healthy: %v", err)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	err = waitForStreamHealthy(ctx, mgr, "MISSING", 0)
+	if err == nil || !strings.Contains(err.Error(), `stream "MISSING" did not become healthy`) {
+		t.Fatalf("expected a timeout for a missing stream, got %v", err)
+	}
+}
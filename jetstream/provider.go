@@ -14,26 +14,37 @@
 package jetstream
 
 import (
+	"context"
 	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var streamIdRegex = regexp.MustCompile("^JETSTREAM_STREAM_(.+)$")
-var consumerIdRegex = regexp.MustCompile("^JETSTREAM_STREAM_(.+?)_CONSUMER_(.+)$")
-var kvIdRegex = regexp.MustCompile("^JETSTREAM_KV_(.+)$")
-var kvEntryIdRegex = regexp.MustCompile("^JETSTREAM_KV_(.+?)_ENTRY_(.+)$")
-var objIdRegex = regexp.MustCompile("^JETSTREAM_OBJ_(.+)$")
+// ids used by earlier versions of the provider, these are still accepted when importing
+// and in stream_id but are ambiguous when names contain the separators
+var legacyStreamIdRegex = regexp.MustCompile("^JETSTREAM_STREAM_(.+)$")
+var legacyConsumerIdRegex = regexp.MustCompile("^JETSTREAM_STREAM_(.+?)_CONSUMER_(.+)$")
+var legacyKVIdRegex = regexp.MustCompile("^JETSTREAM_KV_(.+)$")
+var legacyKVEntryIdRegex = regexp.MustCompile("^JETSTREAM_KV_(.+?)_ENTRY_(.+)$")
+var legacyObjIdRegex = regexp.MustCompile("^JETSTREAM_OBJ_(.+)$")
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"servers": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Comma separated list of NATS servers to connect to",
+				Optional:    true,
+				Description: "Comma separated list of NATS servers to connect to, required unless set using context",
 				DefaultFunc: schema.EnvDefaultFunc("NATS_URL", nil),
 			},
+			"context": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of, or path to, a NATS CLI context to load connection settings from, explicitly set attributes take precedence",
+				DefaultFunc: schema.EnvDefaultFunc("NATS_CONTEXT", nil),
+			},
 			"credentials": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -62,6 +73,58 @@ func Provider() *schema.Provider {
 				Description:   "Connect using a NKEY seed stored in a file",
 				ConflictsWith: []string{"user", "credentials", "credential_data"},
 			},
+			"nkey_data": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Connect using a NKEY seed, the seed is only kept in memory",
+				ConflictsWith: []string{"user", "nkey", "credentials", "credential_data", "token"},
+			},
+			"token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Connect using a token",
+				ConflictsWith: []string{"user", "password", "nkey", "nkey_data", "credentials", "credential_data", "user_jwt"},
+			},
+			"user_jwt": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Connect using a user JWT, the nonce is signed using nkey_data or signer_command",
+				ConflictsWith: []string{"user", "nkey", "credentials", "credential_data", "token"},
+			},
+			"signer_command": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "A command and its arguments that signs the server nonce for user_jwt, the nonce is passed on standard input and the base64 encoded signature is read from standard output",
+				ConflictsWith: []string{"nkey_data"},
+				RequiredWith:  []string{"user_jwt"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"jetstream_domain": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The JetStream domain to manage, used when JetStream is accessed via a leafnode or hub with domains configured",
+				ConflictsWith: []string{"jetstream_api_prefix"},
+			},
+			"jetstream_api_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Subject prefix to use when accessing the JetStream API, typically used when the API is imported from another account",
+				ConflictsWith: []string{"jetstream_domain"},
+			},
+			"request_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The time to wait for a response to each JetStream API request, as a duration like 10s or a number of seconds",
+				ValidateDiagFunc: validateDuration(),
+			},
+			"connect_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The time to wait while establishing the connection to the NATS servers, as a duration like 10s or a number of seconds",
+				ValidateDiagFunc: validateDuration(),
+			},
 			"tls": {
 				Type:     schema.TypeSet,
 				MaxItems: 1,
@@ -98,19 +161,106 @@ func Provider() *schema.Provider {
 							Optional:    true,
 							Description: "The key file content (in PEM format). Needed when NATS server is configured to verify client certificate",
 						},
+						"include_system_roots": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Trust the system root CAs in addition to the CA set using ca_file or ca_file_data",
+						},
+						"insecure_skip_verify": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Skips verification of the server certificate, this is insecure and should only be used for testing",
+						},
+						"server_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The server name to verify the server certificate against, defaults to the host being connected to",
+						},
+						"min_version": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The minimum TLS version to accept, either 1.2 or 1.3",
+							ValidateFunc: validation.StringInSlice([]string{"1.2", "1.3"}, false),
+						},
+						"handshake_first": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Perform the TLS handshake before receiving the INFO protocol from the server, requires handshake_first to be enabled on the server",
+						},
 					},
 				},
 			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"jetstream_stream":     resourceStream(),
-			"jetstream_consumer":   resourceConsumer(),
-			"jetstream_kv_bucket":  resourceKVBucket(),
-			"jetstream_kv_entry":   resourceKVEntry(),
-			"jetstream_obj_bucket": resourceObjBucket(),
+			"jetstream_stream":        resourceStream(),
+			"jetstream_consumer":      resourceConsumer(),
+			"jetstream_kv_bucket":     resourceKVBucket(),
+			"jetstream_kv_entries":    resourceKVEntries(),
+			"jetstream_kv_entry":      resourceKVEntry(),
+			"jetstream_obj_bucket":    resourceObjBucket(),
+			"jetstream_obj_directory": resourceObjDirectory(),
+			"jetstream_obj_link":      resourceObjLink(),
+			"jetstream_obj_object":    resourceObjObject(),
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"jetstream_account":     dataSourceAccount(),
+			"jetstream_consumer":    dataSourceConsumer(),
+			"jetstream_consumers":   dataSourceConsumers(),
+			"jetstream_kv_entry":    dataSourceKVEntry(),
+			"jetstream_kv_history":  dataSourceKVHistory(),
+			"jetstream_kv_keys":     dataSourceKVKeys(),
+			"jetstream_obj_object":  dataSourceObjObject(),
+			"jetstream_obj_objects": dataSourceObjObjects(),
+			"jetstream_stream":      dataSourceStream(),
+			"jetstream_streams":     dataSourceStreams(),
 		},
 
 		ConfigureFunc: connectMgr,
 	}
+
+	for _, r := range p.ResourcesMap {
+		withRequestTimeouts(r)
+	}
+	for _, r := range p.DataSourcesMap {
+		withRequestTimeouts(r)
+	}
+
+	return p
+}
+
+// withRequestTimeouts makes the provider request_timeout available to the retry helpers in every operation of r
+func withRequestTimeouts(r *schema.Resource) {
+	r.CreateContext = requestTimeoutFunc(r.CreateContext)
+	r.ReadContext = requestTimeoutFunc(r.ReadContext)
+	r.UpdateContext = requestTimeoutFunc(r.UpdateContext)
+	r.DeleteContext = requestTimeoutFunc(r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importer := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+			return importer(withRequestTimeout(ctx, m), d, m)
+		}
+	}
+
+	if r.CustomizeDiff != nil {
+		customize := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+			return customize(withRequestTimeout(ctx, m), d, m)
+		}
+	}
+}
+
+func requestTimeoutFunc[F ~func(context.Context, *schema.ResourceData, any) diag.Diagnostics](fn F) F {
+	if fn == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		return fn(withRequestTimeout(ctx, m), d, m)
+	}
 }
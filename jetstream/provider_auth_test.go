@@ -0,0 +1,113 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nkeys"
+)
+
+func createJSAuthServer(t *testing.T, opts *server.Options) (srv *server.Server) {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "")
+	checkErr(t, err, "could not create temporary js store: %v", err)
+
+	opts.Port = -1
+	opts.StoreDir = dir
+	opts.JetStream = true
+
+	srv, err = server.NewServer(opts)
+	checkErr(t, err, "could not start js server: %v", err)
+
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		t.Errorf("nats server did not start")
+	}
+
+	return srv
+}
+
+func TestConnectWithNkeyData(t *testing.T) {
+	kp, err := nkeys.CreateUser()
+	checkErr(t, err, "could not create nkey: %v", err)
+	seed, err := kp.Seed()
+	checkErr(t, err, "could not get seed: %v", err)
+	pub, err := kp.PublicKey()
+	checkErr(t, err, "could not get public key: %v", err)
+
+	srv := createJSAuthServer(t, &server.Options{Nkeys: []*server.NkeyUser{{Nkey: pub}}})
+	defer srv.Shutdown()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{"servers": srv.ClientURL(), "nkey_data": string(seed)})
+	m, err := connectMgr(d)
+	checkErr(t, err, "configure failed: %v", err)
+
+	nc, err := getConnection(m)
+	checkErr(t, err, "connect failed: %v", err)
+	defer m.(*connection).close()
+
+	if !nc.IsConnected() {
+		t.Fatalf("expected to be connected")
+	}
+}
+
+func TestConnectWithToken(t *testing.T) {
+	srv := createJSAuthServer(t, &server.Options{Authorization: "s3cret"})
+	defer srv.Shutdown()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{"servers": srv.ClientURL(), "token": "s3cret"})
+	m, err := connectMgr(d)
+	checkErr(t, err, "configure failed: %v", err)
+
+	nc, err := getConnection(m)
+	checkErr(t, err, "connect failed: %v", err)
+	defer m.(*connection).close()
+
+	if !nc.IsConnected() {
+		t.Fatalf("expected to be connected")
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{"servers": srv.ClientURL(), "token": "wrong"})
+	m, err = connectMgr(d)
+	checkErr(t, err, "configure failed: %v", err)
+
+	_, err = getConnection(m)
+	if err == nil {
+		t.Fatalf("expected an invalid token to fail")
+	}
+}
+
+func TestCommandSigner(t *testing.T) {
+	sig, err := commandSigner([]string{"echo", "aGVsbG8"})([]byte("nonce"))
+	checkErr(t, err, "signing failed: %v", err)
+	if string(sig) != "hello" {
+		t.Fatalf("expected the decoded command output, got %q", sig)
+	}
+
+	_, err = commandSigner([]string{"echo", "not base64!"})([]byte("nonce"))
+	if err == nil {
+		t.Fatalf("expected invalid signatures to fail")
+	}
+
+	_, err = commandSigner([]string{"false"})([]byte("nonce"))
+	if err == nil {
+		t.Fatalf("expected failing commands to fail")
+	}
+}
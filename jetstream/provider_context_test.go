@@ -0,0 +1,141 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats.go"
+)
+
+const testContextConfig = `
+provider "jetstream" {
+  context = "tftest"
+}
+
+resource "jetstream_stream" "test" {
+  name     = "TEST"
+  subjects = ["TEST.*"]
+}
+`
+
+func writeTestContext(t *testing.T, name string, settings map[string]any) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	ctxDir := filepath.Join(dir, "nats", "context")
+	err := os.MkdirAll(ctxDir, 0700)
+	checkErr(t, err, "could not create context directory: %v", err)
+
+	j, err := json.Marshal(settings)
+	checkErr(t, err, "could not encode context: %v", err)
+
+	err = os.WriteFile(filepath.Join(ctxDir, name+".json"), j, 0600)
+	checkErr(t, err, "could not write context: %v", err)
+}
+
+func TestConnectPropertiesFromContext(t *testing.T) {
+	writeTestContext(t, "tftest", map[string]any{
+		"url":              "nats://ctx.example.net:4222",
+		"user":             "ctxuser",
+		"password":         "ctxpass",
+		"ca":               "/ctx/ca.pem",
+		"inbox_prefix":     "_CTX_INBOX",
+		"jetstream_domain": "hub",
+	})
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{"context": "tftest"})
+	props, err := getConnectProperties(d)
+	checkErr(t, err, "could not get properties: %v", err)
+
+	if props.servers != "nats://ctx.example.net:4222" {
+		t.Fatalf("expected servers from context, got %q", props.servers)
+	}
+	if props.user != "ctxuser" || props.pass != "ctxpass" {
+		t.Fatalf("expected user and password from context, got %q and %q", props.user, props.pass)
+	}
+	if props.caFile != "/ctx/ca.pem" {
+		t.Fatalf("expected ca from context, got %q", props.caFile)
+	}
+	if props.inboxPrefix != "_CTX_INBOX" {
+		t.Fatalf("expected inbox prefix from context, got %q", props.inboxPrefix)
+	}
+	if props.domain != "hub" {
+		t.Fatalf("expected domain from context, got %q", props.domain)
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{
+		"context":              "tftest",
+		"servers":              "nats://explicit.example.net:4222",
+		"nkey":                 "/explicit/user.nk",
+		"jetstream_api_prefix": "$JS.explicit.API",
+	})
+	props, err = getConnectProperties(d)
+	checkErr(t, err, "could not get properties: %v", err)
+
+	if props.servers != "nats://explicit.example.net:4222" {
+		t.Fatalf("expected explicit servers, got %q", props.servers)
+	}
+	if props.user != "" || props.pass != "" || props.nkey != "/explicit/user.nk" {
+		t.Fatalf("expected explicit authentication to replace the context, got user %q nkey %q", props.user, props.nkey)
+	}
+	if props.domain != "" || props.apiPrefix != "$JS.explicit.API" {
+		t.Fatalf("expected explicit api prefix to replace the context domain, got domain %q prefix %q", props.domain, props.apiPrefix)
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{"context": "unknown"})
+	_, err = getConnectProperties(d)
+	if err == nil {
+		t.Fatalf("expected unknown contexts to fail")
+	}
+}
+
+func TestProviderWithContext(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+
+	writeTestContext(t, "tftest", map[string]any{"url": nc.ConnectedUrl()})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testStreamDoesNotExist(t, mgr, "TEST"),
+		Steps: []resource.TestStep{
+			{
+				Config: testContextConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testStreamExist(t, mgr, "TEST"),
+				),
+			},
+		},
+	})
+}
@@ -14,6 +14,7 @@
 package jetstream
 
 import (
+	"context"
 	"log"
 	"os"
 	"testing"
@@ -77,6 +78,28 @@ func createJSServer(t *testing.T) (srv *server.Server) {
 	return srv
 }
 
+func createJSDomainServer(t *testing.T, domain string) (srv *server.Server) {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "")
+	checkErr(t, err, "could not create temporary js store: %v", err)
+
+	srv, err = server.NewServer(&server.Options{
+		Port:            -1,
+		StoreDir:        dir,
+		JetStream:       true,
+		JetStreamDomain: domain,
+	})
+	checkErr(t, err, "could not start js server: %v", err)
+
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		t.Errorf("nats server did not start")
+	}
+
+	return srv
+}
+
 func createJSTLSServer(t *testing.T, verifyClientCert bool) (srv *server.Server) {
 	t.Helper()
 
@@ -105,3 +128,82 @@ func createJSTLSServer(t *testing.T, verifyClientCert bool) (srv *server.Server)
 
 	return srv
 }
+
+func TestConnectionReuse(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{"servers": srv.ClientURL()})
+	m, err := connectMgr(d)
+	checkErr(t, err, "configure failed: %v", err)
+
+	nc, err := getConnection(m)
+	checkErr(t, err, "connect failed: %v", err)
+
+	mgr, err := getManager(m)
+	checkErr(t, err, "connect failed: %v", err)
+
+	if mgr.NatsConn() != nc {
+		t.Fatalf("expected the manager to share the provider connection")
+	}
+
+	again, err := getConnection(m)
+	checkErr(t, err, "connect failed: %v", err)
+	if again != nc {
+		t.Fatalf("expected the connection to be reused")
+	}
+
+	nc.Close()
+
+	again, err = getConnection(m)
+	checkErr(t, err, "reconnect failed: %v", err)
+	if again == nc || again.IsClosed() {
+		t.Fatalf("expected a new connection after the previous one was closed")
+	}
+
+	CloseConnections()
+	if !again.IsClosed() {
+		t.Fatalf("expected connections to be closed on shutdown")
+	}
+}
+
+func TestConnectionTimeouts(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{"servers": srv.ClientURL(), "request_timeout": "10", "connect_timeout": "3s"})
+
+	props, err := getConnectProperties(d)
+	checkErr(t, err, "could not get connect properties: %v", err)
+	if props.requestTimeout != 10*time.Second {
+		t.Fatalf("expected a 10s request timeout, got %v", props.requestTimeout)
+	}
+	if props.connectTimeout != 3*time.Second {
+		t.Fatalf("expected a 3s connect timeout, got %v", props.connectTimeout)
+	}
+
+	m, err := connectMgr(d)
+	checkErr(t, err, "configure failed: %v", err)
+	defer m.(*connection).close()
+
+	nc, err := getConnection(m)
+	checkErr(t, err, "connect failed: %v", err)
+	if nc.Opts.Timeout != 3*time.Second {
+		t.Fatalf("expected a 3s connect timeout, got %v", nc.Opts.Timeout)
+	}
+
+	// operations have a deadline so the request timeout is applied to each attempt by the retry helpers
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	rctx, rcancel := requestContext(withRequestTimeout(ctx, m))
+	defer rcancel()
+	deadline, ok := rctx.Deadline()
+	if !ok || time.Until(deadline) > 10*time.Second || time.Until(deadline) < 9*time.Second {
+		t.Fatalf("expected a 10s request timeout, got %v", time.Until(deadline))
+	}
+}
+
+func TestProvider(t *testing.T) {
+	err := Provider().InternalValidate()
+	checkErr(t, err, "invalid provider: %v", err)
+}
@@ -14,6 +14,7 @@
 package jetstream
 
 import (
+	"crypto/tls"
 	"fmt"
 	"testing"
 
@@ -90,6 +91,88 @@ resource "jetstream_stream" "test" {
 }
 `
 
+const testTLSConfig_insecure = `
+provider "jetstream" {
+  servers = "%s"
+  tls {
+    insecure_skip_verify = true
+    server_name          = "localhost"
+    min_version          = "1.3"
+  }
+}
+
+resource "jetstream_stream" "test" {
+  name     = "TEST"
+  subjects = ["TEST.*"]
+}
+`
+
+func TestTLSConfigFromProperties(t *testing.T) {
+	tlsc, err := tlsConfigFromProperties(&connectProperties{})
+	checkErr(t, err, "tls config failed: %v", err)
+	if tlsc != nil {
+		t.Fatalf("expected no tls config without tls settings")
+	}
+
+	tlsc, err = tlsConfigFromProperties(&connectProperties{
+		caData:        caPEM,
+		certData:      certPEM,
+		keyData:       keyPEM,
+		systemRoots:   true,
+		tlsServerName: "nats.example.net",
+		tlsMinVersion: "1.3",
+	})
+	checkErr(t, err, "tls config failed: %v", err)
+
+	if tlsc.RootCAs == nil || len(tlsc.Certificates) != 1 {
+		t.Fatalf("expected root CAs and a client certificate to be loaded")
+	}
+	if tlsc.ServerName != "nats.example.net" {
+		t.Fatalf("expected server name to be set, got %q", tlsc.ServerName)
+	}
+	if tlsc.MinVersion != tls.VersionTLS13 {
+		t.Fatalf("expected TLS 1.3 minimum version, got %v", tlsc.MinVersion)
+	}
+
+	_, err = tlsConfigFromProperties(&connectProperties{certData: certPEM})
+	if err == nil {
+		t.Fatalf("expected a certificate without a key to fail")
+	}
+
+	_, err = tlsConfigFromProperties(&connectProperties{caData: "invalid"})
+	if err == nil {
+		t.Fatalf("expected an invalid CA to fail")
+	}
+}
+
+func TestProviderWithInsecureTLS(t *testing.T) {
+	srv := createJSTLSServer(t, false)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL(), nats.Secure(&tls.Config{InsecureSkipVerify: true}))
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testTLSConfig_insecure, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					testStreamExist(t, mgr, "TEST"),
+				),
+			},
+		},
+	})
+}
+
 func TestProviderWithTLSFromData(t *testing.T) {
 	srv := createJSTLSServer(t, false)
 	defer srv.Shutdown()
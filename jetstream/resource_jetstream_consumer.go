@@ -21,30 +21,31 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
-	"github.com/nats-io/nats.go"
 )
 
 func resourceConsumer() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceConsumerCreate,
-		Read:   resourceConsumerRead,
-		Delete: resourceConsumerDelete,
-		Update: resourceConsumerUpdate,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+	r := &schema.Resource{
+		CreateContext: resourceConsumerCreate,
+		ReadContext:   resourceConsumerRead,
+		DeleteContext: resourceConsumerDelete,
+		UpdateContext: resourceConsumerUpdate,
+		Importer:      importID("consumer", 2, legacyConsumerIdRegex),
+		Timeouts:      resourceTimeouts(),
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"stream_id": {
-				Type:         schema.TypeString,
-				Description:  "The name of the Stream that this consumer consumes",
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+				Type:             schema.TypeString,
+				Description:      "The name of the Stream that this consumer consumes",
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsNotEmpty,
+				DiffSuppressFunc: suppressEquivalentStreamID,
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -132,11 +133,13 @@ func resourceConsumer() *schema.Resource {
 				ForceNew:     true,
 			},
 			"ack_wait": {
-				Type:        schema.TypeInt,
-				Description: "Number of seconds to wait for acknowledgement",
-				Default:     30,
-				Optional:    true,
-				ForceNew:    false,
+				Type:             schema.TypeString,
+				Description:      "How long to wait for acknowledgement, as a duration like 500ms or a number of seconds",
+				Default:          "30",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"max_delivery": {
 				Type:        schema.TypeInt,
@@ -193,11 +196,12 @@ func resourceConsumer() *schema.Resource {
 				ForceNew:     false,
 			},
 			"heartbeat": {
-				Type:        schema.TypeInt,
-				Description: "Enable heartbeat messages for push consumers, duration specified in seconds",
-				Optional:    true,
-				Default:     "",
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      "Enable heartbeat messages for push consumers, as a duration like 10s or a number of seconds",
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"flow_control": {
 				Type:        schema.TypeBool,
@@ -230,25 +234,29 @@ func resourceConsumer() *schema.Resource {
 				ForceNew:    false,
 			},
 			"max_expires": {
-				Type:        schema.TypeInt,
-				Description: "Limits the Pull Expires duration to this maximum in seconds",
-				Optional:    true,
-				Default:     "",
-				ForceNew:    false,
+				Type:             schema.TypeString,
+				Description:      "Limits the Pull Expires duration to this maximum, as a duration like 1m or a number of seconds",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"max_bytes": {
-				Type:        schema.TypeInt,
-				Description: "The maximum bytes value that maybe set when dong a pull on a Pull Consumer",
-				Optional:    true,
-				Default:     "",
-				ForceNew:    false,
+				Type:             schema.TypeString,
+				Description:      "The maximum bytes value that maybe set when dong a pull on a Pull Consumer, as a number of bytes or a size like 1MiB",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateByteSize(),
+				DiffSuppressFunc: suppressEquivalentByteSize,
 			},
 			"inactive_threshold": {
-				Type:        schema.TypeInt,
-				Description: "Removes the consumer after a idle period, specified as a duration in seconds",
-				Default:     "0",
-				Optional:    true,
-				ForceNew:    false,
+				Type:             schema.TypeString,
+				Description:      "Removes the consumer after a idle period, as a duration like 1h or a number of seconds",
+				Default:          "0",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"replicas": {
 				Type:        schema.TypeInt,
@@ -266,10 +274,12 @@ func resourceConsumer() *schema.Resource {
 			},
 			"backoff": {
 				Type:        schema.TypeList,
-				Description: "List of durations in Go format that represents a retry time scale for NaK'd messages. A list of durations in seconds.",
+				Description: "List of durations in Go format that represents a retry time scale for NaK'd messages, bare numbers are seconds",
 				Optional:    true,
 				Elem: &schema.Schema{
-					Type: schema.TypeInt,
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateDuration(),
+					DiffSuppressFunc: suppressEquivalentDuration,
 				},
 			},
 			"priority_policy": {
@@ -288,11 +298,26 @@ func resourceConsumer() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"priority_timeout": {
-				Type:        schema.TypeInt,
-				Description: "For pinned_client priority policy how long before the client times out",
-				Default:     0,
+				Type:             schema.TypeString,
+				Description:      "For pinned_client priority policy how long before the client times out, as a duration like 2m or a number of seconds",
+				Default:          "0",
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
+			},
+			"wait_for_healthy": {
+				Type:        schema.TypeBool,
+				Description: "Waits for the consumer to have an elected leader and all replicas current after creating or updating it",
 				Optional:    true,
-				ForceNew:    true,
+				Default:     false,
+			},
+			"healthy_max_lag": {
+				Type:         schema.TypeInt,
+				Description:  "The number of operations replicas may lag behind the leader and still be considered healthy when using wait_for_healthy",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 		},
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta any) error {
@@ -301,7 +326,7 @@ func resourceConsumer() *schema.Resource {
 			}
 
 			policy := d.Get("priority_policy").(string)
-			timeout := d.Get("priority_timeout").(int)
+			timeout, _ := parseDuration(d.Get("priority_timeout").(string))
 			groupsLen := 0
 			if v, ok := d.GetOk("priority_groups"); ok && v != nil {
 				groupsLen = len(v.([]any))
@@ -322,6 +347,35 @@ func resourceConsumer() *schema.Resource {
 			return nil
 		},
 	}
+
+	unitAttributes := []string{"ack_wait", "heartbeat", "max_expires", "max_bytes", "inactive_threshold", "priority_timeout", "backoff"}
+	r.StateUpgraders = stateUpgraders(r, unitAttributes, upgradeConsumerID)
+
+	return r
+}
+
+// upgradeConsumerID recreates the consumer id and stream_id, the stream name is taken from
+// the legacy stream_id which unlike the legacy consumer id can be parsed unambiguously
+func upgradeConsumerID(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	streamId, _ := rawState["stream_id"].(string)
+	stream, err := parseStreamReference(streamId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot upgrade id %v: %w", rawState["id"], err)
+	}
+
+	durable, _ := rawState["durable_name"].(string)
+	if durable == "" {
+		return nil, fmt.Errorf("cannot upgrade id %v, durable_name is not set", rawState["id"])
+	}
+
+	rawState["id"] = consumerID(stream, durable)
+	rawState["stream_id"] = streamID(stream)
+
+	return rawState, nil
 }
 
 func consumerConfigFromResourceData(d *schema.ResourceData) (cfg api.ConsumerConfig, requiredApiLevel uint, err error) {
@@ -329,7 +383,7 @@ func consumerConfigFromResourceData(d *schema.ResourceData) (cfg api.ConsumerCon
 	cfg = api.ConsumerConfig{
 		Durable:            d.Get("durable_name").(string),
 		Name:               d.Get("durable_name").(string),
-		AckWait:            time.Duration(d.Get("ack_wait").(int)) * time.Second,
+		AckWait:            getDuration(d, "ack_wait"),
 		MaxDeliver:         d.Get("max_delivery").(int),
 		FilterSubject:      d.Get("filter_subject").(string),
 		SampleFrequency:    fmt.Sprintf("%d%%", d.Get("sample_freq").(int)),
@@ -338,14 +392,14 @@ func consumerConfigFromResourceData(d *schema.ResourceData) (cfg api.ConsumerCon
 		RateLimit:          uint64(d.Get("ratelimit").(int)),
 		MaxAckPending:      d.Get("max_ack_pending").(int),
 		FlowControl:        d.Get("flow_control").(bool),
-		Heartbeat:          time.Duration(d.Get("heartbeat").(int)) * time.Second,
+		Heartbeat:          getDuration(d, "heartbeat"),
 		HeadersOnly:        d.Get("headers_only").(bool),
 		MaxRequestBatch:    d.Get("max_batch").(int),
-		MaxRequestExpires:  time.Duration(d.Get("max_expires").(int)) * time.Second,
-		MaxRequestMaxBytes: d.Get("max_bytes").(int),
+		MaxRequestExpires:  getDuration(d, "max_expires"),
+		MaxRequestMaxBytes: int(getByteSize(d, "max_bytes")),
 		Replicas:           d.Get("replicas").(int),
 		MemoryStorage:      d.Get("memory").(bool),
-		InactiveThreshold:  time.Duration(d.Get("inactive_threshold").(int)) * time.Second,
+		InactiveThreshold:  getDuration(d, "inactive_threshold"),
 	}
 
 	if description, ok := d.GetOk("description"); ok {
@@ -363,8 +417,12 @@ func consumerConfigFromResourceData(d *schema.ResourceData) (cfg api.ConsumerCon
 		}
 	}
 
-	for _, d := range d.Get("backoff").([]any) {
-		cfg.BackOff = append(cfg.BackOff, time.Duration(d.(int))*time.Second)
+	for _, v := range d.Get("backoff").([]any) {
+		bo, err := parseDuration(v.(string))
+		if err != nil {
+			return cfg, requiredApiLevel, fmt.Errorf("invalid backoff %q: %w", v, err)
+		}
+		cfg.BackOff = append(cfg.BackOff, bo)
 	}
 
 	seq := uint64(d.Get("stream_sequence").(int))
@@ -459,10 +517,7 @@ func consumerConfigFromResourceData(d *schema.ResourceData) (cfg api.ConsumerCon
 		}
 	}
 
-	pinnedTTL, ok := d.GetOk("priority_timeout")
-	if ok {
-		cfg.PinnedTTL = time.Duration(pinnedTTL.(int)) * time.Second
-	}
+	cfg.PinnedTTL = getDuration(d, "priority_timeout")
 
 	ok, errs := cfg.Validate(new(SchemaValidator))
 	if !ok {
@@ -472,31 +527,30 @@ func consumerConfigFromResourceData(d *schema.ResourceData) (cfg api.ConsumerCon
 	return cfg, requiredApiLevel, nil
 }
 
-func resourceConsumerUpdate(d *schema.ResourceData, m any) error {
+func resourceConsumerUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	stream_id := d.Get("stream_id").(string)
 	if stream_id == "" {
-		return fmt.Errorf("cannot determine stream name for update")
+		return diag.Errorf("cannot determine stream name for update")
 	}
 
-	stream, err := parseStreamID(stream_id)
+	stream, err := parseStreamReference(stream_id)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	durable := d.Get("durable_name").(string)
 	if durable == "" {
-		return fmt.Errorf("cannot determine durable name for update")
+		return diag.Errorf("cannot determine durable name for update")
 	}
 
-	nc, mgr, err := m.(func() (*nats.Conn, *jsm.Manager, error))()
+	mgr, err := getManager(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
 
-	known, err := mgr.IsKnownConsumer(stream, durable)
+	known, err := retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownConsumer(stream, durable) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if !known {
 		d.SetId("")
@@ -505,103 +559,120 @@ func resourceConsumerUpdate(d *schema.ResourceData, m any) error {
 
 	cfg, requiredApiLevel, err := consumerConfigFromResourceData(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	level, err := apiLevel(mgr)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if level < requiredApiLevel {
-		return fmt.Errorf("unsupported api level: %d. Requires NATS API level %d or newer", level, requiredApiLevel)
+		return diag.Errorf("unsupported api level: %d. Requires NATS API level %d or newer", level, requiredApiLevel)
 	}
 
 	// We call NewconsumerFromDefault because of the idempotent way consumers are created/updated
 	// If the consumer already exists, it will be updated, and if not we'll exit before we get here
-	_, err = mgr.NewConsumerFromDefault(stream, cfg)
+	_, err = retryValue(ctx, func(context.Context) (*jsm.Consumer, error) { return mgr.NewConsumerFromDefault(stream, cfg) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceConsumerRead(d, m)
+	if d.Get("wait_for_healthy").(bool) {
+		err = waitForConsumerHealthy(ctx, mgr, stream, durable, uint64(d.Get("healthy_max_lag").(int)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceConsumerRead(ctx, d, m)
 }
 
-func resourceConsumerCreate(d *schema.ResourceData, m any) error {
+func resourceConsumerCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	cfg, requiredApiLevel, err := consumerConfigFromResourceData(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	stream, err := parseStreamID(d.Get("stream_id").(string))
+	stream, err := parseStreamReference(d.Get("stream_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	nc, mgr, err := m.(func() (*nats.Conn, *jsm.Manager, error))()
+	mgr, err := getManager(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
 
 	level, err := apiLevel(mgr)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if level < requiredApiLevel {
-		return fmt.Errorf("unsupported api level: %d. Requires NATS API level %d or newer", level, requiredApiLevel)
+		return diag.Errorf("unsupported api level: %d. Requires NATS API level %d or newer", level, requiredApiLevel)
 	}
 
-	_, err = mgr.NewConsumerFromDefault(stream, cfg)
+	_, err = retryValue(ctx, func(context.Context) (*jsm.Consumer, error) { return mgr.NewConsumerFromDefault(stream, cfg) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.SetId(fmt.Sprintf("JETSTREAM_STREAM_%s_CONSUMER_%s", stream, cfg.Durable))
+	d.SetId(consumerID(stream, cfg.Durable))
+
+	if d.Get("wait_for_healthy").(bool) {
+		err = waitForConsumerHealthy(ctx, mgr, stream, cfg.Durable, uint64(d.Get("healthy_max_lag").(int)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-	return resourceConsumerRead(d, m)
+	return resourceConsumerRead(ctx, d, m)
 }
 
-func resourceConsumerRead(d *schema.ResourceData, m any) error {
+func resourceConsumerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	stream, name, err := parseConsumerID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	nc, mgr, err := m.(func() (*nats.Conn, *jsm.Manager, error))()
+	mgr, err := getManager(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
 
-	known, err := mgr.IsKnownStream(stream)
+	known, err := retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownStream(stream) })
 	if err != nil {
-		return fmt.Errorf("could not determine if stream %q is known: %s", name, err)
+		return diag.Errorf("could not determine if stream %q is known: %s", name, err)
 	}
 	if !known {
 		d.SetId("")
 		return nil
 	}
 
-	known, err = mgr.IsKnownConsumer(stream, name)
+	known, err = retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownConsumer(stream, name) })
 	if err != nil {
-		return fmt.Errorf("could not determine if %q > %q is a known consumer: %s", stream, name, err)
+		return diag.Errorf("could not determine if %q > %q is a known consumer: %s", stream, name, err)
 	}
 	if !known {
 		d.SetId("")
 		return nil
 	}
 
-	cons, err := mgr.LoadConsumer(stream, name)
+	cons, err := retryValue(ctx, func(context.Context) (*jsm.Consumer, error) { return mgr.LoadConsumer(stream, name) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.Set("stream_id", fmt.Sprintf("JETSTREAM_STREAM_%s", stream))
+	return diag.FromErr(setConsumerResourceData(d, stream, cons))
+}
+
+// setConsumerResourceData sets the configuration of cons on d, shared by the consumer resource and data sources
+func setConsumerResourceData(d *schema.ResourceData, stream string, cons *jsm.Consumer) error {
+	d.Set("stream_id", streamID(stream))
 	d.Set("description", cons.Description())
 	d.Set("metadata", jsm.FilterServerMetadata(cons.Metadata()))
 	d.Set("durable_name", cons.DurableName())
 	d.Set("delivery_subject", cons.DeliverySubject())
-	d.Set("ack_wait", cons.AckWait().Seconds())
+	setDuration(d, "ack_wait", cons.AckWait())
 	d.Set("max_delivery", cons.MaxDeliver())
 	d.Set("filter_subject", cons.FilterSubject())
 	d.Set("filter_subjects", cons.FilterSubjects())
@@ -609,19 +680,19 @@ func resourceConsumerRead(d *schema.ResourceData, m any) error {
 	d.Set("start_time", "")
 	d.Set("ratelimit", cons.RateLimit())
 	d.Set("max_ack_pending", cons.MaxAckPending())
-	d.Set("heartbeat", cons.Heartbeat().Seconds())
+	setDuration(d, "heartbeat", cons.Heartbeat())
 	d.Set("flow_control", cons.FlowControl())
 	d.Set("max_waiting", cons.MaxWaiting())
 	d.Set("delivery_group", cons.DeliverGroup())
 	d.Set("headers_only", cons.IsHeadersOnly())
 	d.Set("max_batch", cons.MaxRequestBatch())
-	d.Set("max_expires", cons.MaxRequestExpires().Seconds())
-	d.Set("max_bytes", cons.MaxRequestMaxBytes())
+	setDuration(d, "max_expires", cons.MaxRequestExpires())
+	setByteSize(d, "max_bytes", int64(cons.MaxRequestMaxBytes()))
 	d.Set("replicas", cons.Replicas())
 	d.Set("memory", cons.MemoryStorage())
-	d.Set("inactive_threshold", cons.InactiveThreshold().Seconds())
+	setDuration(d, "inactive_threshold", cons.InactiveThreshold())
 	d.Set("priority_groups", cons.PriorityGroups())
-	d.Set("priority_timeout", cons.Configuration().PinnedTTL.Seconds())
+	setDuration(d, "priority_timeout", cons.Configuration().PinnedTTL)
 
 	switch cons.DeliverPolicy() {
 	case api.DeliverAll:
@@ -683,40 +754,54 @@ func resourceConsumerRead(d *schema.ResourceData, m any) error {
 		d.Set("priority_policy", "prioritized")
 	}
 
-	var bo []any
-	for _, d := range cons.Backoff() {
-		bo = append(bo, d.Seconds())
+	// keep the configured backoff when it is equivalent to avoid differences like 1m versus 60
+	configured := d.Get("backoff").([]any)
+	equivalent := len(configured) == len(cons.Backoff())
+	bo := make([]any, len(cons.Backoff()))
+	for i, v := range cons.Backoff() {
+		bo[i] = formatDuration(v)
+		if equivalent {
+			cv, err := parseDuration(configured[i].(string))
+			equivalent = err == nil && cv == v
+		}
+	}
+	if !equivalent {
+		d.Set("backoff", bo)
 	}
-	d.Set("backoff", bo)
 
 	return nil
 }
 
-func resourceConsumerDelete(d *schema.ResourceData, m any) error {
+func resourceConsumerDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	streamName, durableName, err := parseConsumerID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	nc, mgr, err := m.(func() (*nats.Conn, *jsm.Manager, error))()
+	mgr, err := getManager(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
 
-	known, err := mgr.IsKnownConsumer(streamName, durableName)
+	known, err := retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownConsumer(streamName, durableName) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if !known {
 		d.SetId("")
 		return nil
 	}
 
-	cons, err := mgr.LoadConsumer(streamName, durableName)
+	cons, err := retryValue(ctx, func(context.Context) (*jsm.Consumer, error) { return mgr.LoadConsumer(streamName, durableName) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return cons.Delete()
+	err = retry(ctx, func(context.Context) error { return cons.Delete() })
+	// a previous attempt that timed out might have deleted the consumer already
+	if err != nil && !api.IsNatsErr(err, 10014) {
+		return diag.FromErr(err)
+	}
+
+	return nil
 }
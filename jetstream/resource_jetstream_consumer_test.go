@@ -18,6 +18,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/nats-io/jsm.go"
@@ -51,6 +52,28 @@ resource "jetstream_consumer" "TEST_C1" {
 }
 `
 
+const testConsumerConfig_durations = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_stream" "test" {
+  name     = "TEST"
+  subjects = ["TEST.*"]
+}
+
+resource "jetstream_consumer" "TEST_C1" {
+  stream_id          = jetstream_stream.test.id
+  durable_name       = "C1"
+  deliver_all        = true
+  ack_wait           = "1500ms"
+  backoff            = ["1500ms", "1m"]
+  inactive_threshold = "1h"
+  max_bytes          = "1MiB"
+  max_batch          = 1
+}
+`
+
 const testConsumerConfig_str10 = `
 provider "jetstream" {
   servers = "%s"
@@ -496,3 +519,37 @@ func TestResourceConsumer(t *testing.T) {
 		},
 	})
 }
+
+func TestResourceConsumerDurations(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testConsumerDoesNotExist(t, mgr, "TEST", "C1"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testConsumerConfig_durations, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					testConsumerExist(t, mgr, "TEST", "C1"),
+					testConsumerHasDurations(t, mgr, "TEST", "C1", 1500*time.Millisecond, time.Hour),
+					resource.TestCheckResourceAttr("jetstream_consumer.TEST_C1", "ack_wait", "1500ms"),
+					resource.TestCheckResourceAttr("jetstream_consumer.TEST_C1", "backoff.1", "1m"),
+					resource.TestCheckResourceAttr("jetstream_consumer.TEST_C1", "inactive_threshold", "1h"),
+					resource.TestCheckResourceAttr("jetstream_consumer.TEST_C1", "max_bytes", "1MiB"),
+				),
+			},
+		},
+	})
+}
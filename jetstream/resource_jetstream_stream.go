@@ -14,14 +14,16 @@
 package jetstream
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
-	"github.com/nats-io/nats.go"
 )
 
 func resourceStream() *schema.Resource {
@@ -87,6 +89,12 @@ func resourceStream() *schema.Resource {
 						Required:    false,
 						Optional:    true,
 					},
+					"domain": {
+						Type:        schema.TypeString,
+						Description: "The JetStream domain the source stream is in, sets the API prefix to $JS.<domain>.API and is exclusive to api",
+						Required:    false,
+						Optional:    true,
+					},
 				},
 			},
 		},
@@ -112,14 +120,14 @@ func resourceStream() *schema.Resource {
 		},
 	}
 
-	return &schema.Resource{
-		Create: resourceStreamCreate,
-		Read:   resourceStreamRead,
-		Update: resourceStreamUpdate,
-		Delete: resourceStreamDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+	r := &schema.Resource{
+		CreateContext: resourceStreamCreate,
+		ReadContext:   resourceStreamRead,
+		UpdateContext: resourceStreamUpdate,
+		DeleteContext: resourceStreamDelete,
+		Importer:      importID("stream", 1, legacyStreamIdRegex),
+		Timeouts:      resourceTimeouts(),
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -178,28 +186,36 @@ func resourceStream() *schema.Resource {
 				Default:     -1,
 			},
 			"max_bytes": {
-				Type:        schema.TypeInt,
-				Description: "The maximum size of all messages that can be kept in the stream",
-				Optional:    true,
-				Default:     -1,
+				Type:             schema.TypeString,
+				Description:      "The maximum size of all messages that can be kept in the stream, as a number of bytes or a size like 10GiB, -1 for unlimited",
+				Default:          "-1",
+				Optional:         true,
+				ValidateDiagFunc: validateByteSize(),
+				DiffSuppressFunc: suppressEquivalentByteSize,
 			},
 			"max_age": {
-				Type:        schema.TypeInt,
-				Description: "The maximum oldest message that can be kept in the stream, duration specified in seconds",
-				Optional:    true,
-				Default:     0,
+				Type:             schema.TypeString,
+				Description:      "The maximum oldest message that can be kept in the stream, as a duration like 1h or a number of seconds",
+				Default:          "0",
+				Optional:         true,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"duplicate_window": {
-				Type:        schema.TypeInt,
-				Description: "The size of the duplicate tracking windows, duration specified in seconds",
-				Optional:    true,
-				Default:     120,
+				Type:             schema.TypeString,
+				Description:      "The size of the duplicate tracking windows, as a duration like 2m or a number of seconds",
+				Default:          "120",
+				Optional:         true,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"max_msg_size": {
-				Type:        schema.TypeInt,
-				Description: "The maximum individual message size that the stream will accept",
-				Optional:    true,
-				Default:     -1,
+				Type:             schema.TypeString,
+				Description:      "The maximum individual message size that the stream will accept, as a number of bytes or a size like 1MiB, -1 for unlimited",
+				Default:          "-1",
+				Optional:         true,
+				ValidateDiagFunc: validateByteSizeAtMost(math.MaxInt32),
+				DiffSuppressFunc: suppressEquivalentByteSize,
 			},
 			"storage": {
 				Type:             schema.TypeString,
@@ -342,10 +358,12 @@ func resourceStream() *schema.Resource {
 				Optional:    true,
 			},
 			"inactive_threshold": {
-				Type:        schema.TypeInt,
-				Description: "Duration that instructs the server to clean up consumers inactive for that long",
-				ForceNew:    false,
-				Optional:    true,
+				Type:             schema.TypeString,
+				Description:      "Duration that instructs the server to clean up consumers inactive for that long, as a duration like 1h or a number of seconds",
+				ForceNew:         false,
+				Optional:         true,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"allow_msg_ttl": {
 				Type:        schema.TypeBool,
@@ -355,10 +373,12 @@ func resourceStream() *schema.Resource {
 				Optional:    true,
 			},
 			"subject_delete_marker_ttl": {
-				Type:        schema.TypeInt,
-				Description: "When placing a marker, how long should it be valid, duration specified in seconds. allow_rollup_hdrs must be set to true when using this field.",
-				ForceNew:    false,
-				Optional:    true,
+				Type:             schema.TypeString,
+				Description:      "When placing a marker, how long should it be valid, as a duration like 1h or a number of seconds. allow_rollup_hdrs must be set to true when using this field.",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"allow_msg_counter": {
 				Type:        schema.TypeBool,
@@ -408,66 +428,93 @@ func resourceStream() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"wait_for_healthy": {
+				Type:        schema.TypeBool,
+				Description: "Waits for the stream to have an elected leader and all replicas current after creating or updating it",
+				Optional:    true,
+				Default:     false,
+			},
+			"healthy_max_lag": {
+				Type:         schema.TypeInt,
+				Description:  "The number of operations replicas may lag behind the leader and still be considered healthy when using wait_for_healthy",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 	}
+
+	unitAttributes := []string{"max_age", "duplicate_window", "max_bytes", "max_msg_size", "subject_delete_marker_ttl"}
+	// inactive_threshold was a number of nanoseconds rather than seconds
+	r.StateUpgraders = stateUpgraders(r, append(unitAttributes, "inactive_threshold"), upgradeNanosecondsToDurations("inactive_threshold"), upgradeID("name"))
+
+	return r
 }
 
-func resourceStreamCreate(d *schema.ResourceData, m any) error {
+func resourceStreamCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	cfg, requiredApiLevel, err := streamConfigFromResourceData(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	nc, mgr, err := m.(func() (*nats.Conn, *jsm.Manager, error))()
+	mgr, err := getManager(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
 
 	level, err := apiLevel(mgr)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if level < requiredApiLevel {
-		return fmt.Errorf("unsupported api level: %d. Requires NATS API level %d or newer", level, requiredApiLevel)
+		return diag.Errorf("unsupported api level: %d. Requires NATS API level %d or newer", level, requiredApiLevel)
 	}
 
-	_, err = mgr.NewStreamFromDefault(cfg.Name, cfg)
+	_, err = retryValue(ctx, func(context.Context) (*jsm.Stream, error) { return mgr.NewStreamFromDefault(cfg.Name, cfg) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.SetId(fmt.Sprintf("JETSTREAM_STREAM_%s", cfg.Name))
+	d.SetId(streamID(cfg.Name))
 
-	return resourceStreamRead(d, m)
+	err = waitForResourceHealthy(ctx, d, m, cfg.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStreamRead(ctx, d, m)
 }
 
-func resourceStreamRead(d *schema.ResourceData, m any) error {
+func resourceStreamRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	name, err := parseStreamID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	nc, mgr, err := m.(func() (*nats.Conn, *jsm.Manager, error))()
+	mgr, err := getManager(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
 
-	known, err := mgr.IsKnownStream(name)
+	known, err := retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownStream(name) })
 	if err != nil {
-		return fmt.Errorf("could not determine if stream %q is known: %s", name, err)
+		return diag.Errorf("could not determine if stream %q is known: %s", name, err)
 	}
 	if !known {
 		d.SetId("")
 		return nil
 	}
 
-	str, err := mgr.LoadStream(name)
+	str, err := retryValue(ctx, func(context.Context) (*jsm.Stream, error) { return mgr.LoadStream(name) })
 	if err != nil {
-		return fmt.Errorf("could not load stream %q: %s", name, err)
+		return diag.Errorf("could not load stream %q: %s", name, err)
 	}
 
+	return diag.FromErr(setStreamResourceData(d, str))
+}
+
+// setStreamResourceData sets the configuration of str on d, shared by the stream resource and data source
+func setStreamResourceData(d *schema.ResourceData, str *jsm.Stream) error {
 	compressionBytes, err := str.Compression().MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("could not decode compression value %s: %s", str.Compression().String(), err)
@@ -481,10 +528,10 @@ func resourceStreamRead(d *schema.ResourceData, m any) error {
 	d.Set("max_consumers", str.MaxConsumers())
 	d.Set("max_msgs", int(str.MaxMsgs()))
 	d.Set("max_msgs_per_subject", int(str.MaxMsgsPerSubject()))
-	d.Set("max_age", int(str.MaxAge().Seconds()))
-	d.Set("duplicate_window", str.DuplicateWindow().Seconds())
-	d.Set("max_bytes", str.MaxBytes())
-	d.Set("max_msg_size", int(str.MaxMsgSize()))
+	setDuration(d, "max_age", str.MaxAge())
+	setDuration(d, "duplicate_window", str.DuplicateWindow())
+	setByteSize(d, "max_bytes", str.MaxBytes())
+	setByteSize(d, "max_msg_size", int64(str.MaxMsgSize()))
 	d.Set("replicas", str.Replicas())
 	d.Set("ack", !str.NoAck())
 	d.Set("deny_delete", !str.DeleteAllowed())
@@ -494,9 +541,9 @@ func resourceStreamRead(d *schema.ResourceData, m any) error {
 	d.Set("discard_new_per_subject", str.DiscardNewPerSubject())
 	d.Set("compression", compression)
 	d.Set("max_ack_pending", str.ConsumerLimits().MaxAckPending)
-	d.Set("inactive_threshold", str.ConsumerLimits().InactiveThreshold)
+	setDuration(d, "inactive_threshold", str.ConsumerLimits().InactiveThreshold)
 	d.Set("allow_msg_ttl", str.AllowMsgTTL())
-	d.Set("subject_delete_marker_ttl", str.SubjectDeleteMarkerTTL().Seconds())
+	setDuration(d, "subject_delete_marker_ttl", str.SubjectDeleteMarkerTTL())
 	d.Set("allow_msg_counter", str.CounterAllowed())
 	d.Set("allow_atomic", str.AtomicBatchPublishAllowed())
 	d.Set("allow_msg_schedules", str.SchedulesAllowed())
@@ -552,16 +599,17 @@ func resourceStreamRead(d *schema.ResourceData, m any) error {
 	if str.IsMirror() {
 		mirror := str.Mirror()
 		mirrors := []map[string]any{
-			streamSourceConfigRead(mirror),
+			streamSourceConfigRead(mirror, configuredSourceDomains(d, "mirror")),
 		}
 		d.Set("mirror_direct", str.MirrorDirectAllowed())
 		d.Set("mirror", mirrors)
 	}
 
 	if str.IsSourced() {
+		domains := configuredSourceDomains(d, "source")
 		sources := make([]map[string]any, len(str.Sources()))
 		for i, source := range str.Sources() {
-			sources[i] = streamSourceConfigRead(source)
+			sources[i] = streamSourceConfigRead(source, domains)
 		}
 		d.Set("source", sources)
 	}
@@ -575,7 +623,34 @@ func resourceStreamRead(d *schema.ResourceData, m any) error {
 	return nil
 }
 
-func streamSourceConfigRead(source *api.StreamSource) map[string]any {
+// configuredSourceDomains maps the external api prefix of configured domains to the domain, the
+// same stream can be sourced from several domains so sources are matched by prefix not name
+func configuredSourceDomains(d *schema.ResourceData, key string) map[string]string {
+	domains := map[string]string{}
+
+	for _, sd := range d.Get(key).([]any) {
+		s, ok := sd.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		exts, ok := s["external"].([]any)
+		if !ok || len(exts) == 0 || exts[0] == nil {
+			continue
+		}
+
+		domain := exts[0].(map[string]any)["domain"].(string)
+		if domain != "" {
+			domains[externalDomainApiPrefix(domain)] = domain
+		}
+	}
+
+	return domains
+}
+
+// streamSourceConfigRead reads a source into the resource representation, domains are the
+// configured external domains by source name and are used to represent the api prefix as a domain
+func streamSourceConfigRead(source *api.StreamSource, domains map[string]string) map[string]any {
 	sourceConfig := map[string]any{}
 	sourceConfig["name"] = source.Name
 	sourceConfig["filter_subject"] = source.FilterSubject
@@ -586,12 +661,18 @@ func streamSourceConfigRead(source *api.StreamSource) map[string]any {
 	}
 
 	if source.External != nil {
-		sourceConfig["external"] = []map[string]any{
-			{
-				"api":     source.External.ApiPrefix,
-				"deliver": source.External.DeliverPrefix,
-			},
+		external := map[string]any{
+			"api":     source.External.ApiPrefix,
+			"deliver": source.External.DeliverPrefix,
+			"domain":  "",
 		}
+
+		if domain, ok := domains[source.External.ApiPrefix]; ok {
+			external["api"] = ""
+			external["domain"] = domain
+		}
+
+		sourceConfig["external"] = []map[string]any{external}
 	}
 
 	if source.Consumer != nil {
@@ -617,72 +698,81 @@ func streamSourceConfigRead(source *api.StreamSource) map[string]any {
 	return sourceConfig
 }
 
-func resourceStreamUpdate(d *schema.ResourceData, m any) error {
+func resourceStreamUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	name := d.Get("name").(string)
 
-	nc, mgr, err := m.(func() (*nats.Conn, *jsm.Manager, error))()
+	mgr, err := getManager(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
 
-	known, err := mgr.IsKnownStream(name)
+	known, err := retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownStream(name) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if !known {
 		d.SetId("")
 		return nil
 	}
 
-	str, err := mgr.LoadStream(name)
+	str, err := retryValue(ctx, func(context.Context) (*jsm.Stream, error) { return mgr.LoadStream(name) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cfg, requiredApiLevel, err := streamConfigFromResourceData(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	level, err := apiLevel(mgr)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if level < requiredApiLevel {
-		return fmt.Errorf("unsupported api level: %d. Requires NATS API level %d or newer", level, requiredApiLevel)
+		return diag.Errorf("unsupported api level: %d. Requires NATS API level %d or newer", level, requiredApiLevel)
+	}
+
+	err = retry(ctx, func(context.Context) error { return str.UpdateConfiguration(cfg) })
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	err = str.UpdateConfiguration(cfg)
+	err = waitForResourceHealthy(ctx, d, m, cfg.Name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceStreamRead(d, m)
+	return resourceStreamRead(ctx, d, m)
 }
 
-func resourceStreamDelete(d *schema.ResourceData, m any) error {
+func resourceStreamDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	name := d.Get("name").(string)
 
-	nc, mgr, err := m.(func() (*nats.Conn, *jsm.Manager, error))()
+	mgr, err := getManager(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
 
-	known, err := mgr.IsKnownStream(name)
+	known, err := retryValue(ctx, func(context.Context) (bool, error) { return mgr.IsKnownStream(name) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if !known {
 		d.SetId("")
 		return nil
 	}
 
-	str, err := mgr.LoadStream(name)
+	str, err := retryValue(ctx, func(context.Context) (*jsm.Stream, error) { return mgr.LoadStream(name) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	err = retry(ctx, func(context.Context) error { return str.Delete() })
+	// a previous attempt that timed out might have deleted the stream already
+	if err != nil && !api.IsNatsErr(err, 10059) {
+		return diag.FromErr(err)
 	}
 
-	return str.Delete()
+	return nil
 }
@@ -38,11 +38,30 @@ resource "jetstream_stream" "test" {
     allow_msg_ttl = true
     subject_delete_marker_ttl = 24*60*60
 	allow_rollup_hdrs = true
+	inactive_threshold = 60
 	metadata = {
 		foo = "bar"
 	}
 }`
 
+const testStreamConfigTimeouts = `
+provider "jetstream" {
+	servers = "%s"
+	request_timeout = 10
+	connect_timeout = 5
+}
+
+resource "jetstream_stream" "test" {
+	name = "TEST"
+	subjects = ["TEST.*"]
+	wait_for_healthy = true
+
+	timeouts {
+		create = "1m"
+		delete = "1m"
+	}
+}`
+
 const testStreamConfigOtherSubjects = `
 provider "jetstream" {
 	servers = "%s"
@@ -53,6 +72,7 @@ resource "jetstream_stream" "test" {
 	subjects = ["OTHER.*"]
 	max_msgs = 10
 	max_msgs_per_subject = 2
+	inactive_threshold = "1h30m"
 }
 `
 
@@ -311,6 +331,90 @@ resource "jetstream_stream" "sourced" {
 }
 `
 
+const testStreamExternalDomain = `
+provider "jetstream" {
+	servers          = "%s"
+	jetstream_domain = "hub"
+}
+
+resource "jetstream_stream" "domain_source" {
+  name = "DOMAIN_SOURCE"
+  source {
+    name = "ORDERS"
+    external {
+      domain = "leaf"
+    }
+  }
+}
+`
+
+const testStreamExternalDomains = `
+provider "jetstream" {
+	servers          = "%s"
+	jetstream_domain = "hub"
+}
+
+resource "jetstream_stream" "domain_source" {
+  name = "DOMAIN_SOURCE"
+  source {
+    name = "ORDERS"
+    external {
+      domain = "leaf1"
+    }
+  }
+  source {
+    name = "ORDERS"
+    external {
+      domain = "leaf2"
+    }
+  }
+}
+`
+
+func TestStreamExternalDomain(t *testing.T) {
+	srv := createJSDomainServer(t, "hub")
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc, jsm.WithDomain("hub"))
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testStreamDoesNotExist(t, mgr, "DOMAIN_SOURCE"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testStreamExternalDomain, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					testStreamExist(t, mgr, "DOMAIN_SOURCE"),
+					testStreamSourceHasApiPrefix(t, mgr, "DOMAIN_SOURCE", "ORDERS", "$JS.leaf.API"),
+					resource.TestCheckResourceAttr("jetstream_stream.domain_source", "source.0.external.0.domain", "leaf"),
+					resource.TestCheckResourceAttr("jetstream_stream.domain_source", "source.0.external.0.api", ""),
+				),
+			},
+			{
+				// the same stream sourced from several domains
+				Config: fmt.Sprintf(testStreamExternalDomains, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					testStreamExist(t, mgr, "DOMAIN_SOURCE"),
+					resource.TestCheckResourceAttr("jetstream_stream.domain_source", "source.#", "2"),
+					resource.TestCheckResourceAttr("jetstream_stream.domain_source", "source.0.external.0.domain", "leaf1"),
+					resource.TestCheckResourceAttr("jetstream_stream.domain_source", "source.0.external.0.api", ""),
+					resource.TestCheckResourceAttr("jetstream_stream.domain_source", "source.1.external.0.domain", "leaf2"),
+					resource.TestCheckResourceAttr("jetstream_stream.domain_source", "source.1.external.0.api", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestStreamFirstSeq(t *testing.T) {
 	srv := createJSServer(t)
 	defer srv.Shutdown()
@@ -411,6 +515,37 @@ func TestStreamSourceConsumer(t *testing.T) {
 	})
 }
 
+func TestResourceStreamTimeouts(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	if err != nil {
+		t.Fatalf("could not connect: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testStreamDoesNotExist(t, mgr, "TEST"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testStreamConfigTimeouts, nc.ConnectedUrl()),
+				Check: resource.ComposeTestCheckFunc(
+					testStreamExist(t, mgr, "TEST"),
+					resource.TestCheckResourceAttr("jetstream_stream.test", "name", "TEST"),
+					resource.TestCheckResourceAttr("jetstream_stream.test", "wait_for_healthy", "true"),
+				),
+			},
+		},
+	})
+}
+
 func TestResourceStream(t *testing.T) {
 	srv := createJSServer(t)
 	defer srv.Shutdown()
@@ -447,6 +582,7 @@ func TestResourceStream(t *testing.T) {
 					resource.TestCheckResourceAttr("jetstream_stream.test", "deny_delete", "false"),
 					resource.TestCheckResourceAttr("jetstream_stream.test", "compression", "s2"),
 					resource.TestCheckResourceAttr("jetstream_stream.test", "allow_msg_ttl", "true"),
+					resource.TestCheckResourceAttr("jetstream_stream.test", "inactive_threshold", "60"),
 				),
 			},
 			{
@@ -457,6 +593,7 @@ func TestResourceStream(t *testing.T) {
 					testStreamHasSubjects(t, mgr, "TEST", []string{"OTHER.*"}),
 					resource.TestCheckResourceAttr("jetstream_stream.test", "max_msgs", "10"),
 					resource.TestCheckResourceAttr("jetstream_stream.test", "max_msgs_per_subject", "2"),
+					resource.TestCheckResourceAttr("jetstream_stream.test", "inactive_threshold", "1h30m"),
 				),
 			},
 			{
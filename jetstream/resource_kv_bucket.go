@@ -16,9 +16,9 @@ package jetstream
 import (
 	"context"
 	"errors"
-	"fmt"
-	"time"
+	"math"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/nats-io/nats.go"
@@ -26,14 +26,14 @@ import (
 )
 
 func resourceKVBucket() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceKVBucketCreate,
-		Read:   resourceKVBucketRead,
-		Update: resourceKVBucketUpdate,
-		Delete: resourceKVBucketDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+	r := &schema.Resource{
+		CreateContext: resourceKVBucketCreate,
+		ReadContext:   resourceKVBucketRead,
+		UpdateContext: resourceKVBucketUpdate,
+		DeleteContext: resourceKVBucketDelete,
+		Importer:      importID("kv bucket", 1, legacyKVIdRegex),
+		Timeouts:      resourceTimeouts(),
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -65,28 +65,31 @@ func resourceKVBucket() *schema.Resource {
 				ValidateFunc: validation.All(validation.IntAtLeast(0), validation.IntAtMost(128)),
 			},
 			"ttl": {
-				Type:         schema.TypeInt,
-				Description:  "How many seconds a value will be kept in the bucket",
-				Optional:     true,
-				ForceNew:     false,
-				Default:      0,
-				ValidateFunc: validation.IntAtLeast(0),
+				Type:             schema.TypeString,
+				Description:      "How long a value will be kept in the bucket, as a duration like 24h or a number of seconds",
+				Default:          "0",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"max_value_size": {
-				Type:         schema.TypeInt,
-				Description:  "Maximum size of any value",
-				Default:      -1,
-				Optional:     true,
-				ForceNew:     false,
-				ValidateFunc: validation.IntAtLeast(-1),
+				Type:             schema.TypeString,
+				Description:      "Maximum size of any value, as a number of bytes or a size like 1MiB, -1 for unlimited",
+				Default:          "-1",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateByteSizeAtMost(math.MaxInt32),
+				DiffSuppressFunc: suppressEquivalentByteSize,
 			},
 			"max_bucket_size": {
-				Type:         schema.TypeInt,
-				Description:  "Maximum size of the entire bucket",
-				Default:      -1,
-				Optional:     true,
-				ForceNew:     false,
-				ValidateFunc: validation.IntAtLeast(-1),
+				Type:             schema.TypeString,
+				Description:      "Maximum size of the entire bucket, as a number of bytes or a size like 10GiB, -1 for unlimited",
+				Default:          "-1",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateByteSize(),
+				DiffSuppressFunc: suppressEquivalentByteSize,
 			},
 			"placement_cluster": {
 				Type:        schema.TypeString,
@@ -111,32 +114,45 @@ func resourceKVBucket() *schema.Resource {
 				ValidateFunc: validation.All(validation.IntAtLeast(1), validation.IntAtMost(5)),
 			},
 			"limit_marker_ttl": {
+				Type:             schema.TypeString,
+				Description:      "Enables Per-Key TTLs and Limit Markers, as a duration like 1h or a number of seconds",
+				Default:          "0",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
+			},
+			"wait_for_healthy": {
+				Type:        schema.TypeBool,
+				Description: "Waits for the bucket to have an elected leader and all replicas current after creating or updating it",
+				Optional:    true,
+				Default:     false,
+			},
+			"healthy_max_lag": {
 				Type:         schema.TypeInt,
-				Description:  "Enables Per-Key TTLs and Limit Markers, duration specified in seconds",
+				Description:  "The number of operations replicas may lag behind the leader and still be considered healthy when using wait_for_healthy",
 				Optional:     true,
-				ForceNew:     false,
 				Default:      0,
 				ValidateFunc: validation.IntAtLeast(0),
 			},
 		},
 	}
-}
 
-func resourceKVBucketCreate(d *schema.ResourceData, m any) error {
-	nc, err := getConnection(d, m)
-	if err != nil {
-		return err
-	}
-	defer nc.Close()
+	unitAttributes := []string{"ttl", "max_value_size", "max_bucket_size", "limit_marker_ttl"}
+	r.StateUpgraders = stateUpgraders(r, unitAttributes, upgradeID("name"))
+
+	return r
+}
 
+func resourceKVBucketCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	name := d.Get("name").(string)
 	history := d.Get("history").(int)
-	ttl := d.Get("ttl").(int)
-	maxV := d.Get("max_value_size").(int)
-	maxB := d.Get("max_bucket_size").(int)
+	ttl := getDuration(d, "ttl")
+	maxV := getByteSize(d, "max_value_size")
+	maxB := getByteSize(d, "max_bucket_size")
 	replicas := d.Get("replicas").(int)
 	descrption := d.Get("description").(string)
-	limit_marker_ttl := d.Get("limit_marker_ttl").(int)
+	limit_marker_ttl := getDuration(d, "limit_marker_ttl")
 
 	var storage jetstream.StorageType
 	switch d.Get("storage").(string) {
@@ -161,77 +177,75 @@ func resourceKVBucketCreate(d *schema.ResourceData, m any) error {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	js, err := jetstream.New(nc)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	known, err := js.KeyValue(ctx, name)
+	known, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, name) })
 	if known != nil {
-		return fmt.Errorf("bucket %s already exist", name)
+		return diag.Errorf("bucket %s already exist", name)
 	} else if err != nil {
 		if !errors.Is(err, jetstream.ErrBucketNotFound) {
-			return fmt.Errorf("failed to load KV bucket: %s", err)
+			return diag.Errorf("failed to load KV bucket: %s", err)
 		}
 	}
 
-	js.CreateKeyValue(ctx, jetstream.KeyValueConfig{
+	cfg := jetstream.KeyValueConfig{
 		Bucket:         name,
 		Description:    descrption,
 		MaxValueSize:   int32(maxV),
 		History:        uint8(history),
-		TTL:            time.Duration(ttl) * time.Second,
-		MaxBytes:       int64(maxB),
+		TTL:            ttl,
+		MaxBytes:       maxB,
 		Storage:        storage,
 		Replicas:       replicas,
 		Placement:      placement,
-		LimitMarkerTTL: time.Duration(limit_marker_ttl) * time.Second,
-	})
+		LimitMarkerTTL: limit_marker_ttl,
+	}
 
-	d.SetId(fmt.Sprintf("JETSTREAM_KV_%s", name))
+	_, err = retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.CreateKeyValue(ctx, cfg) })
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	return resourceKVBucketRead(d, m)
-}
+	d.SetId(kvBucketID(name))
 
-func resourceKVBucketRead(d *schema.ResourceData, m any) error {
-	name, err := parseStreamKVID(d.Id())
+	err = waitForResourceHealthy(ctx, d, m, "KV_"+name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	nc, err := getConnection(d, m)
+	return resourceKVBucketRead(ctx, d, m)
+}
+
+func resourceKVBucketRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	name, err := parseKVBucketID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
 
-	js, err := jetstream.New(nc)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	bucket, err := js.KeyValue(ctx, name)
+	bucket, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, name) })
 	if err != nil {
 		if errors.Is(err, jetstream.ErrBucketNotFound) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
-	status, err := bucket.Status(ctx)
+	status, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValueStatus, error) { return bucket.Status(ctx) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", status.Bucket())
 	d.Set("history", status.History())
-	d.Set("ttl", status.TTL().Seconds())
+	setDuration(d, "ttl", status.TTL())
 
 	jStatus := status.(*jetstream.KeyValueBucketStatus)
 	si := jStatus.StreamInfo()
@@ -243,8 +257,8 @@ func resourceKVBucketRead(d *schema.ResourceData, m any) error {
 		d.Set("storage", "memory")
 	}
 
-	d.Set("max_value_size", si.Config.MaxMsgSize)
-	d.Set("max_bucket_size", si.Config.MaxBytes)
+	setByteSize(d, "max_value_size", int64(si.Config.MaxMsgSize))
+	setByteSize(d, "max_bucket_size", si.Config.MaxBytes)
 	d.Set("replicas", si.Config.Replicas)
 	d.Set("description", si.Config.Description)
 
@@ -253,41 +267,34 @@ func resourceKVBucketRead(d *schema.ResourceData, m any) error {
 		d.Set("placement_tags", si.Config.Placement.Tags)
 	}
 
-	d.Set("limit_marker_ttl", si.Config.SubjectDeleteMarkerTTL.Seconds())
+	setDuration(d, "limit_marker_ttl", si.Config.SubjectDeleteMarkerTTL)
 
 	return nil
 }
 
-func resourceKVBucketUpdate(d *schema.ResourceData, m any) error {
+func resourceKVBucketUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	name := d.Get("name").(string)
 
-	nc, err := getConnection(d, m)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	js, err := jetstream.New(nc)
-	if err != nil {
-		return err
-	}
-	bucket, err := js.KeyValue(ctx, name)
+	bucket, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, name) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	status, err := bucket.Status(ctx)
+	status, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValueStatus, error) { return bucket.Status(ctx) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	jStatus := status.(*jetstream.KeyValueBucketStatus)
 
-	str, err := js.Stream(ctx, jStatus.StreamInfo().Config.Name)
+	str, err := retryValue(ctx, func(ctx context.Context) (jetstream.Stream, error) {
+		return js.Stream(ctx, jStatus.StreamInfo().Config.Name)
+	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cfg := jetstream.KeyValueConfig{
@@ -307,11 +314,11 @@ func resourceKVBucketUpdate(d *schema.ResourceData, m any) error {
 	}
 
 	history := d.Get("history").(int)
-	ttl := d.Get("ttl").(int)
-	maxV := d.Get("max_value_size").(int)
-	maxB := d.Get("max_bucket_size").(int)
+	ttl := getDuration(d, "ttl")
+	maxV := getByteSize(d, "max_value_size")
+	maxB := getByteSize(d, "max_bucket_size")
 	description := d.Get("description").(string)
-	markerTTL := d.Get("limit_marker_ttl").(int)
+	markerTTL := getDuration(d, "limit_marker_ttl")
 
 	var placement *jetstream.Placement
 	if cluster, ok := d.GetOk("placement_cluster"); ok {
@@ -327,42 +334,38 @@ func resourceKVBucketUpdate(d *schema.ResourceData, m any) error {
 	}
 
 	cfg.History = uint8(history)
-	cfg.TTL = time.Duration(ttl) * time.Second
+	cfg.TTL = ttl
 	cfg.MaxValueSize = int32(maxV)
-	cfg.MaxBytes = int64(maxB)
+	cfg.MaxBytes = maxB
 	cfg.Description = description
-	cfg.LimitMarkerTTL = time.Duration(markerTTL) * time.Second
+	cfg.LimitMarkerTTL = markerTTL
 	cfg.Placement = placement
 
-	_, err = js.CreateOrUpdateKeyValue(ctx, cfg)
+	_, err = retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.CreateOrUpdateKeyValue(ctx, cfg) })
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = waitForResourceHealthy(ctx, d, m, "KV_"+name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceKVBucketRead(d, m)
+	return resourceKVBucketRead(ctx, d, m)
 }
 
-func resourceKVBucketDelete(d *schema.ResourceData, m any) error {
+func resourceKVBucketDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	name := d.Get("name").(string)
 
-	nc, err := getConnection(d, m)
-	if err != nil {
-		return err
-	}
-	defer nc.Close()
-
-	js, err := jetstream.New(nc)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	err = js.DeleteKeyValue(ctx, name)
-	if err == nats.ErrStreamNotFound {
+	err = retry(ctx, func(ctx context.Context) error { return js.DeleteKeyValue(ctx, name) })
+	if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
 		return nil
 	} else if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
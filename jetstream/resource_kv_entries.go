@@ -0,0 +1,320 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func resourceKVEntries() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKVEntriesCreate,
+		ReadContext:   resourceKVEntriesRead,
+		UpdateContext: resourceKVEntriesUpdate,
+		DeleteContext: resourceKVEntriesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKVEntriesImport,
+		},
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Description:  "The name of the bucket",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "A prefix added to every key in entries, only keys with this prefix are pruned",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"entries": {
+				Type:        schema.TypeMap,
+				Description: "The keys, without the prefix, and their values",
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"prune": {
+				Type:         schema.TypeString,
+				Description:  "Removes keys with the prefix that are not in entries, none leaves them, delete adds a delete marker and purge also removes their history",
+				Optional:     true,
+				Default:      "none",
+				ValidateFunc: validation.StringInSlice([]string{"none", "delete", "purge"}, false),
+			},
+		},
+	}
+}
+
+func resourceKVEntriesImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	bucket, prefix, err := splitPrefixID(d.Id(), "kv entries")
+	if err != nil {
+		return nil, err
+	}
+
+	kv, err := kvEntriesBucket(ctx, m, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	// all keys with the prefix are adopted as there are no entries in the state yet
+	keys, err := kvEntriesKeys(ctx, kv, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := kvEntriesValues(ctx, kv, prefix, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("bucket", bucket)
+	d.Set("prefix", prefix)
+	d.Set("entries", entries)
+	d.Set("prune", "none")
+	d.SetId(prefixID(bucket, prefix))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceKVEntriesCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+
+	kv, err := kvEntriesBucket(ctx, m, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = applyKVEntries(ctx, d, kv, map[string]any{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(prefixID(bucket, prefix))
+
+	return resourceKVEntriesRead(ctx, d, m)
+}
+
+func resourceKVEntriesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+
+	kv, err := kvEntriesBucket(ctx, m, bucket)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// when pruning every key with the prefix is read so unknown keys show up for removal,
+	// otherwise only the keys being managed are read
+	var keys []string
+	if d.Get("prune").(string) == "none" {
+		for key := range d.Get("entries").(map[string]any) {
+			keys = append(keys, prefix+key)
+		}
+	} else {
+		keys, err = kvEntriesKeys(ctx, kv, prefix)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	entries, err := kvEntriesValues(ctx, kv, prefix, keys)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("entries", entries)
+
+	return nil
+}
+
+func resourceKVEntriesUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	kv, err := kvEntriesBucket(ctx, m, d.Get("bucket").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	old, _ := d.GetChange("entries")
+
+	err = applyKVEntries(ctx, d, kv, old.(map[string]any))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKVEntriesRead(ctx, d, m)
+}
+
+func resourceKVEntriesDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	prefix := d.Get("prefix").(string)
+
+	kv, err := kvEntriesBucket(ctx, m, d.Get("bucket").(string))
+	if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	purge := d.Get("prune").(string) == "purge"
+	for key := range d.Get("entries").(map[string]any) {
+		err = removeKVEntry(ctx, kv, prefix+key, purge)
+		if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+			return nil
+		} else if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func kvEntriesBucket(ctx context.Context, m any, bucket string) (jetstream.KeyValue, error) {
+	js, err := getJetStream(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, bucket) })
+}
+
+// kvEntriesKeys lists the keys starting with prefix, the server filters when the prefix is whole tokens
+func kvEntriesKeys(ctx context.Context, kv jetstream.KeyValue, prefix string) ([]string, error) {
+	filter := ">"
+	if strings.HasSuffix(prefix, ".") {
+		filter = prefix + ">"
+	}
+
+	keys, err := retryValue(ctx, func(ctx context.Context) ([]string, error) { return listKVKeys(ctx, kv, filter) })
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) && key != prefix {
+			matched = append(matched, key)
+		}
+	}
+
+	return matched, nil
+}
+
+// kvEntriesValues reads the values of keys, keys that do not exist are left out
+func kvEntriesValues(ctx context.Context, kv jetstream.KeyValue, prefix string, keys []string) (map[string]any, error) {
+	entries := make(map[string]any, len(keys))
+	for _, key := range keys {
+		entry, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValueEntry, error) { return kv.Get(ctx, key) })
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("could not read key %q: %w", key, err)
+		}
+
+		entries[strings.TrimPrefix(key, prefix)] = string(entry.Value())
+	}
+
+	return entries, nil
+}
+
+// applyKVEntries writes the entries that differ from old and removes keys that are no longer
+// in entries, when pruning all other keys with the prefix are removed too
+func applyKVEntries(ctx context.Context, d *schema.ResourceData, kv jetstream.KeyValue, old map[string]any) error {
+	prefix := d.Get("prefix").(string)
+	entries := d.Get("entries").(map[string]any)
+	prune := d.Get("prune").(string)
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := entries[key].(string)
+		current, ok := old[key]
+		if ok && current.(string) == value {
+			continue
+		}
+
+		err := retryUnapplied(ctx, func(ctx context.Context) error {
+			_, err := kv.PutString(ctx, prefix+key, value)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("could not put key %q: %w", prefix+key, err)
+		}
+	}
+
+	remove := map[string]bool{}
+	for key := range old {
+		if _, ok := entries[key]; !ok {
+			remove[prefix+key] = true
+		}
+	}
+
+	if prune != "none" {
+		existing, err := kvEntriesKeys(ctx, kv, prefix)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range existing {
+			if _, ok := entries[strings.TrimPrefix(key, prefix)]; !ok {
+				remove[key] = true
+			}
+		}
+	}
+
+	for key := range remove {
+		err := removeKVEntry(ctx, kv, key, prune == "purge")
+		if err != nil {
+			return fmt.Errorf("could not remove key %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// removeKVEntry deletes or purges key, deleting a key that does not exist is not an error
+// while purging it still removes its history
+func removeKVEntry(ctx context.Context, kv jetstream.KeyValue, key string, purge bool) error {
+	if purge {
+		return retryUnapplied(ctx, func(ctx context.Context) error { return kv.Purge(ctx, key) })
+	}
+
+	_, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValueEntry, error) { return kv.Get(ctx, key) })
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return retryUnapplied(ctx, func(ctx context.Context) error { return kv.Delete(ctx, key) })
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const testKVEntries_basic = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_kv_bucket" "test" {
+  name    = "TEST"
+  history = 5
+}
+
+resource "jetstream_kv_entries" "test" {
+  bucket  = jetstream_kv_bucket.test.name
+  prefix  = "app."
+  prune   = "%s"
+  entries = {
+%s
+  }
+}
+`
+
+func TestResourceKVEntries(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	config := func(prune string, entries string) string {
+		return fmt.Sprintf(testKVEntries_basic, nc.ConnectedUrl(), prune, entries)
+	}
+
+	put := func(key string, value string) {
+		kv, err := js.KeyValue(ctx, "TEST")
+		checkErr(t, err, "could not load bucket: %v", err)
+		_, err = kv.PutString(ctx, key, value)
+		checkErr(t, err, "could not put: %v", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testEntryDoesNotExist(ctx, t, js, "TEST", "app.db"),
+			testEntryDoesNotExist(ctx, t, js, "TEST", "app.port"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config("none", `"db" = "postgres", "port" = "5432"`),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "app.db", []byte("postgres")),
+					testEntryHasValue(ctx, t, js, "TEST", "app.port", []byte("5432")),
+					resource.TestCheckResourceAttr("jetstream_kv_entries.test", "id", "TEST/app."),
+					resource.TestCheckResourceAttr("jetstream_kv_entries.test", "entries.%", "2"),
+				),
+			},
+			{
+				// keys not in entries are left alone when not pruning
+				PreConfig: func() {
+					put("app.other", "kept")
+					put("other", "kept")
+				},
+				Config: config("none", `"db" = "mysql", "port" = "5432"`),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "app.db", []byte("mysql")),
+					testEntryHasValue(ctx, t, js, "TEST", "app.other", []byte("kept")),
+					resource.TestCheckResourceAttr("jetstream_kv_entries.test", "entries.%", "2"),
+				),
+			},
+			{
+				// values changed outside of terraform are restored
+				PreConfig: func() {
+					put("app.port", "1234")
+				},
+				Config: config("none", `"db" = "mysql", "port" = "5432"`),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "app.port", []byte("5432")),
+				),
+			},
+			{
+				// pruning removes keys with the prefix that are not in entries
+				Config: config("delete", `"db" = "mysql"`),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "app.db", []byte("mysql")),
+					testEntryDoesNotExist(ctx, t, js, "TEST", "app.port"),
+					testEntryDoesNotExist(ctx, t, js, "TEST", "app.other"),
+					testEntryHasValue(ctx, t, js, "TEST", "other", []byte("kept")),
+					resource.TestCheckResourceAttr("jetstream_kv_entries.test", "entries.%", "1"),
+				),
+			},
+			{
+				// keys added outside of terraform are pruned on the next apply
+				PreConfig: func() {
+					put("app.extra", "removed")
+				},
+				Config: config("purge", `"db" = "mysql"`),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryDoesNotExist(ctx, t, js, "TEST", "app.extra"),
+					testEntryHistoryLength(ctx, t, js, "TEST", "app.extra", 1),
+				),
+			},
+			{
+				ResourceName:            "jetstream_kv_entries.test",
+				ImportState:             true,
+				ImportStateId:           "TEST/app.",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"prune", "timeouts"},
+			},
+		},
+	})
+}
+
+func testEntryHistoryLength(ctx context.Context, t *testing.T, js jetstream.JetStream, bucket string, key string, length int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		kv, err := js.KeyValue(ctx, bucket)
+		if err != nil {
+			return err
+		}
+
+		history, err := kv.History(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if len(history) != length {
+			return fmt.Errorf("expected %d history entries for %q got %d", length, key, len(history))
+		}
+
+		return nil
+	}
+}
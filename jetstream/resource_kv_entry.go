@@ -15,23 +15,34 @@ package jetstream
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"time"
+	"os"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 )
 
+// kvEntryValueAttributes are the mutually exclusive ways of setting the value of an entry
+var kvEntryValueAttributes = []string{"value", "value_base64", "value_json", "value_file"}
+
 func resourceKVEntry() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceKVEntryCreate,
-		Read:   resourceKVEntryRead,
-		Update: resourceKVEntryUpdate,
-		Delete: resourceKVEntryDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+	r := &schema.Resource{
+		CreateContext: resourceKVEntryCreate,
+		ReadContext:   resourceKVEntryRead,
+		UpdateContext: resourceKVEntryUpdate,
+		DeleteContext: resourceKVEntryDelete,
+		Importer:      importID("kv entry", 2, legacyKVEntryIdRegex),
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: resourceKVEntryCustomizeDiff,
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"bucket": {
@@ -47,10 +58,67 @@ func resourceKVEntry() *schema.Resource {
 				ForceNew:    true,
 			},
 			"value": {
+				Type:         schema.TypeString,
+				Description:  "The value of the entry",
+				Optional:     true,
+				ForceNew:     false,
+				ExactlyOneOf: kvEntryValueAttributes,
+			},
+			"value_base64": {
+				Type:         schema.TypeString,
+				Description:  "The value of the entry encoded as base64, for binary values",
+				Optional:     true,
+				ForceNew:     false,
+				ExactlyOneOf: kvEntryValueAttributes,
+				ValidateFunc: validation.StringIsBase64,
+			},
+			"value_json": {
+				Type:             schema.TypeString,
+				Description:      "The value of the entry as JSON, differences in whitespace and key order are ignored",
+				Optional:         true,
+				ForceNew:         false,
+				ExactlyOneOf:     kvEntryValueAttributes,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+			"value_file": {
+				Type:         schema.TypeString,
+				Description:  "The path to a file holding the value of the entry",
+				Optional:     true,
+				ForceNew:     false,
+				ExactlyOneOf: kvEntryValueAttributes,
+			},
+			"value_file_sha256": {
 				Type:        schema.TypeString,
-				Description: "The value of the entry",
-				Required:    true,
-				ForceNew:    false,
+				Description: "The SHA-256 of the value when using value_file, used to detect changes to the file or the entry",
+				Computed:    true,
+			},
+			"ttl": {
+				Type:             schema.TypeString,
+				Description:      "Removes the key after this duration, requires a bucket with limit_marker_ttl set",
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
+			},
+			"create_only": {
+				Type:        schema.TypeBool,
+				Description: "Fail when creating the entry if the key already exists rather than overwriting it",
+				Optional:    true,
+				Default:     false,
+			},
+			"destroy_mode": {
+				Type:         schema.TypeString,
+				Description:  "How the key is removed when the entry is destroyed, delete keeps previous revisions in the history while purge removes them",
+				Optional:     true,
+				Default:      "delete",
+				ValidateFunc: validation.StringInSlice([]string{"delete", "purge"}, false),
+			},
+			"optimistic_lock": {
+				Type:        schema.TypeBool,
+				Description: "Fail updates when the entry was changed since its revision was last read rather than overwriting it",
+				Optional:    true,
+				Default:     false,
 			},
 			"revision": {
 				Type:        schema.TypeInt,
@@ -59,141 +127,279 @@ func resourceKVEntry() *schema.Resource {
 			},
 		},
 	}
-}
 
-func resourceKVEntryCreate(d *schema.ResourceData, m any) error {
-	nc, err := getConnection(d, m)
-	if err != nil {
-		return err
-	}
-	defer nc.Close()
+	r.StateUpgraders = stateUpgraders(r, nil, upgradeID("bucket", "key"))
 
+	return r
+}
+
+func resourceKVEntryCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	bucket := d.Get("bucket").(string)
 	key := d.Get("key").(string)
-	value := d.Get("value").(string)
-
-	js, err := jetstream.New(nc)
+	value, err := kvEntryValue(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
 
-	kv, err := js.KeyValue(ctx, bucket)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	_, err = kv.Put(ctx, key, []byte(value))
+	kv, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, bucket) })
 	if err != nil {
+		return diag.FromErr(err)
+	}
+	createOnly := d.Get("create_only").(bool)
+	ttl := getDuration(d, "ttl")
+
+	err = retryUnapplied(ctx, func(ctx context.Context) error {
+		var err error
+		switch {
+		case ttl > 0:
+			// a ttl can only be set by creating the key, so unless create_only is set any
+			// existing value is deleted first
+			_, err = kv.Create(ctx, key, value, jetstream.KeyTTL(ttl))
+			if err != nil && isKVConflict(err) && !createOnly {
+				err = kv.Delete(ctx, key)
+				if err != nil {
+					return err
+				}
+				_, err = kv.Create(ctx, key, value, jetstream.KeyTTL(ttl))
+			}
+		case createOnly:
+			_, err = kv.Create(ctx, key, value)
+		default:
+			_, err = kv.Put(ctx, key, value)
+		}
 		return err
+	})
+	if err != nil {
+		if isKVConflict(err) {
+			return diag.Errorf("key %q already exists in bucket %q, import it or disable create_only", key, bucket)
+		}
+		return diag.FromErr(err)
 	}
 
-	d.SetId(fmt.Sprintf("JETSTREAM_KV_%s_ENTRY_%s", bucket, key))
+	d.SetId(kvEntryID(bucket, key))
 
-	return resourceKVEntryRead(d, m)
+	return resourceKVEntryRead(ctx, d, m)
 }
 
-func resourceKVEntryRead(d *schema.ResourceData, m any) error {
-	bucket, key, err := parseStreamKVEntryID(d.Id())
+func resourceKVEntryRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket, key, err := parseKVEntryID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	nc, err := getConnection(d, m)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
-
-	js, err := jetstream.New(nc)
-	if err != nil {
-		return err
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	kv, err := js.KeyValue(ctx, bucket)
+	kv, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, bucket) })
 	if err != nil {
 		if errors.Is(err, jetstream.ErrBucketNotFound) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
-	entry, err := kv.Get(ctx, key)
+	entry, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValueEntry, error) { return kv.Get(ctx, key) })
 	if err != nil {
 		if errors.Is(err, jetstream.ErrKeyNotFound) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("bucket", entry.Bucket())
 	d.Set("key", entry.Key())
-	d.Set("value", string(entry.Value()))
 	d.Set("revision", entry.Revision())
 
+	// only the attribute in use is set, the others would otherwise show as removed
+	switch {
+	case d.Get("value_base64").(string) != "":
+		d.Set("value_base64", base64.StdEncoding.EncodeToString(entry.Value()))
+	case d.Get("value_json").(string) != "":
+		d.Set("value_json", string(entry.Value()))
+	case d.Get("value_file").(string) != "":
+		d.Set("value_file_sha256", sha256Hex(entry.Value()))
+	default:
+		d.Set("value", string(entry.Value()))
+	}
+
 	return nil
 }
 
-func resourceKVEntryUpdate(d *schema.ResourceData, m any) error {
+func resourceKVEntryUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	bucket := d.Get("bucket").(string)
 
-	nc, err := getConnection(d, m)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
-
-	js, err := jetstream.New(nc)
+	kv, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, bucket) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
 
-	kv, err := js.KeyValue(ctx, bucket)
+	key := d.Get("key").(string)
+	value, err := kvEntryValue(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	key := d.Get("key").(string)
-	value := d.Get("value").(string)
+	// the revision is the one last read, so a write made since then is a conflict when locking
+	revision := uint64(d.Get("revision").(int))
+	lock := d.Get("optimistic_lock").(bool)
 
-	_, err = kv.Put(ctx, key, []byte(value))
-	if err != nil {
+	err = retryUnapplied(ctx, func(ctx context.Context) error {
+		var err error
+		if lock {
+			_, err = kv.Update(ctx, key, value, revision)
+		} else {
+			_, err = kv.Put(ctx, key, value)
+		}
 		return err
+	})
+	if err != nil {
+		if lock && isKVConflict(err) {
+			return diag.Errorf("key %q in bucket %q was changed after revision %d was read, refresh to review the change and apply again", key, bucket, revision)
+		}
+		return diag.FromErr(err)
 	}
 
-	return resourceKVEntryRead(d, m)
+	return resourceKVEntryRead(ctx, d, m)
 }
 
-func resourceKVEntryDelete(d *schema.ResourceData, m any) error {
+func resourceKVEntryDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
 
-	nc, err := getConnection(d, m)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	kv, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, bucket) })
+	if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = removeKVEntry(ctx, kv, key, d.Get("destroy_mode").(string) == "purge")
+	if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKVEntryCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	// the file content is not part of the configuration, so its hash is compared with the
+	// hash of the stored value to detect changes to either
+	if path, ok := d.GetOk("value_file"); ok && d.NewValueKnown("value_file") {
+		content, err := os.ReadFile(path.(string))
+		if err != nil {
+			return fmt.Errorf("could not read value_file: %w", err)
+		}
+
+		hash := sha256Hex(content)
+		if d.Get("value_file_sha256").(string) != hash {
+			err = d.SetNew("value_file_sha256", hash)
+			if err != nil {
+				return err
+			}
+		}
 	}
-	defer nc.Close()
 
-	js, err := jetstream.New(nc)
+	ttl, ok := d.GetOk("ttl")
+	if !ok {
+		return nil
+	}
+
+	// keys with a ttl can not be updated in place as only creating a key sets its ttl
+	if d.Id() != "" {
+		for _, k := range append(kvEntryValueAttributes, "value_file_sha256") {
+			if d.HasChange(k) {
+				err := d.ForceNew(k)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if !d.NewValueKnown("bucket") || !d.NewValueKnown("ttl") {
+		return nil
+	}
+
+	return validateKVBucketAllowsTTL(ctx, m, d.Get("bucket").(string), ttl.(string))
+}
+
+// validateKVBucketAllowsTTL checks that bucket supports per-key ttls, buckets that do not exist yet
+// are assumed to be created in the same apply and are checked by the server
+func validateKVBucketAllowsTTL(ctx context.Context, m any, bucket string, ttl string) error {
+	js, err := getJetStream(m)
 	if err != nil {
 		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
 
-	kv, err := js.KeyValue(ctx, bucket)
+	kv, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValue, error) { return js.KeyValue(ctx, bucket) })
+	if errors.Is(err, jetstream.ErrBucketNotFound) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
-	err = kv.Delete(ctx, d.Get("key").(string))
+
+	status, err := retryValue(ctx, func(ctx context.Context) (jetstream.KeyValueStatus, error) { return kv.Status(ctx) })
 	if err != nil {
 		return err
 	}
 
+	if status.LimitMarkerTTL() == 0 {
+		return fmt.Errorf("ttl %s can not be set on keys in bucket %q as it does not have limit_marker_ttl set", ttl, bucket)
+	}
+
 	return nil
 }
+
+// kvEntryValue is the value to store based on which of the value attributes is set
+func kvEntryValue(d *schema.ResourceData) ([]byte, error) {
+	if v, ok := d.GetOk("value_base64"); ok {
+		value, err := base64.StdEncoding.DecodeString(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value_base64: %w", err)
+		}
+		return value, nil
+	}
+
+	if v, ok := d.GetOk("value_json"); ok {
+		return []byte(v.(string)), nil
+	}
+
+	if v, ok := d.GetOk("value_file"); ok {
+		value, err := os.ReadFile(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("could not read value_file: %w", err)
+		}
+		return value, nil
+	}
+
+	return []byte(d.Get("value").(string)), nil
+}
+
+// isKVConflict determines if err is due to the key having a different revision than expected
+func isKVConflict(err error) bool {
+	var apiErr *jetstream.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode == jetstream.JSErrCodeStreamWrongLastSequence
+}
+
+func sha256Hex(v []byte) string {
+	sum := sha256.Sum256(v)
+	return hex.EncodeToString(sum[:])
+}
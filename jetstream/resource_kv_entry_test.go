@@ -14,13 +14,19 @@
 package jetstream
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/nats.go"
@@ -241,3 +247,351 @@ func TestResourceKVEntryBucketExternalDeletion(t *testing.T) {
 		},
 	})
 }
+
+const testKVEntry_values = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_kv_bucket" "test" {
+  name = "TEST"
+}
+
+resource "jetstream_kv_entry" "binary" {
+  bucket       = jetstream_kv_bucket.test.name
+  key          = "binary"
+  value_base64 = "AAEC/w=="
+}
+
+resource "jetstream_kv_entry" "json" {
+  bucket     = jetstream_kv_bucket.test.name
+  key        = "json"
+  value_json = %q
+}
+
+resource "jetstream_kv_entry" "file" {
+  bucket     = jetstream_kv_bucket.test.name
+  key        = "file"
+  value_file = %q
+}
+`
+
+func TestResourceKVEntryValues(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	file := filepath.Join(t.TempDir(), "value.txt")
+	err = os.WriteFile(file, []byte("from file"), 0600)
+	checkErr(t, err, "could not write file: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testEntryDoesNotExist(ctx, t, js, "TEST", "binary"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testKVEntry_values, nc.ConnectedUrl(), `{"a":1,"b":[1,2]}`, file),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "binary", []byte{0, 1, 2, 255}),
+					testEntryHasValue(ctx, t, js, "TEST", "json", []byte(`{"a":1,"b":[1,2]}`)),
+					testEntryHasValue(ctx, t, js, "TEST", "file", []byte("from file")),
+					resource.TestCheckResourceAttr("jetstream_kv_entry.binary", "value_base64", "AAEC/w=="),
+					resource.TestCheckNoResourceAttr("jetstream_kv_entry.binary", "value"),
+					resource.TestCheckResourceAttr("jetstream_kv_entry.file", "value_file_sha256", sha256Hex([]byte("from file"))),
+				),
+			},
+			{
+				// reformatted json is not a change
+				Config:   fmt.Sprintf(testKVEntry_values, nc.ConnectedUrl(), "{\n  \"b\": [1, 2],\n  \"a\": 1\n}", file),
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					err = os.WriteFile(file, []byte("changed file"), 0600)
+					checkErr(t, err, "could not write file: %v", err)
+				},
+				Config: fmt.Sprintf(testKVEntry_values, nc.ConnectedUrl(), `{"a":2}`, file),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "json", []byte(`{"a":2}`)),
+					testEntryHasValue(ctx, t, js, "TEST", "file", []byte("changed file")),
+					resource.TestCheckResourceAttr("jetstream_kv_entry.file", "value_file_sha256", sha256Hex([]byte("changed file"))),
+				),
+			},
+			{
+				// a change made outside of terraform is put back
+				PreConfig: func() {
+					kv, err := js.KeyValue(ctx, "TEST")
+					checkErr(t, err, "could not load bucket: %v", err)
+					_, err = kv.PutString(ctx, "file", "external")
+					checkErr(t, err, "could not put: %v", err)
+				},
+				Config: fmt.Sprintf(testKVEntry_values, nc.ConnectedUrl(), `{"a":2}`, file),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "file", []byte("changed file")),
+				),
+			},
+		},
+	})
+}
+
+func testEntryHasValue(ctx context.Context, t *testing.T, js jetstream.JetStream, bucket string, key string, value []byte) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		kv, err := js.KeyValue(ctx, bucket)
+		if err != nil {
+			return err
+		}
+
+		entry, err := kv.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(entry.Value(), value) {
+			return fmt.Errorf("expected value %q got %q", value, entry.Value())
+		}
+
+		return nil
+	}
+}
+
+func TestKVEntryConflicts(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	pd := schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{"servers": srv.ClientURL()})
+	m, err := connectMgr(pd)
+	checkErr(t, err, "configure failed: %v", err)
+	defer m.(*connection).close()
+
+	js, err := getJetStream(m)
+	checkErr(t, err, "connect failed: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	kv, err := js.CreateKeyValue(ctx, jetstream.KeyValueConfig{Bucket: "TEST", History: 5})
+	checkErr(t, err, "could not create bucket: %v", err)
+	_, err = kv.PutString(ctx, "existing", "app")
+	checkErr(t, err, "could not put: %v", err)
+
+	d := schema.TestResourceDataRaw(t, resourceKVEntry().Schema, map[string]any{"bucket": "TEST", "key": "existing", "value": "tf", "create_only": true})
+	diags := resourceKVEntryCreate(ctx, d, m)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, `key "existing" already exists in bucket "TEST"`) {
+		t.Fatalf("expected an existing key error, got %v", diags)
+	}
+	testEntryValue(ctx, t, kv, "existing", "app")
+
+	d = schema.TestResourceDataRaw(t, resourceKVEntry().Schema, map[string]any{"bucket": "TEST", "key": "new", "value": "tf", "create_only": true, "optimistic_lock": true})
+	diags = resourceKVEntryCreate(ctx, d, m)
+	if diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	if d.Get("revision").(int) != 2 {
+		t.Fatalf("expected revision 2 got %d", d.Get("revision").(int))
+	}
+
+	// a write made after the revision was read is a conflict
+	_, err = kv.PutString(ctx, "new", "app")
+	checkErr(t, err, "could not put: %v", err)
+
+	d.Set("value", "tf updated")
+	diags = resourceKVEntryUpdate(ctx, d, m)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "was changed after revision 2 was read") {
+		t.Fatalf("expected a conflict error, got %v", diags)
+	}
+	testEntryValue(ctx, t, kv, "new", "app")
+
+	// once refreshed the update succeeds
+	diags = resourceKVEntryRead(ctx, d, m)
+	if diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	d.Set("value", "tf updated")
+	diags = resourceKVEntryUpdate(ctx, d, m)
+	if diags.HasError() {
+		t.Fatalf("update failed: %v", diags)
+	}
+	testEntryValue(ctx, t, kv, "new", "tf updated")
+}
+
+func testEntryValue(ctx context.Context, t *testing.T, kv jetstream.KeyValue, key string, value string) {
+	t.Helper()
+
+	entry, err := kv.Get(ctx, key)
+	checkErr(t, err, "could not get key: %v", err)
+	if string(entry.Value()) != value {
+		t.Fatalf("expected %q to be %q got %q", key, value, entry.Value())
+	}
+}
+
+const testKVEntry_ttl = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_kv_bucket" "test" {
+  name             = "TEST"
+  history          = 5
+  limit_marker_ttl = "1s"
+}
+
+resource "jetstream_kv_entry" "test" {
+  bucket = jetstream_kv_bucket.test.name
+  key    = "session"
+  value  = "%s"
+  ttl    = "5s"
+}
+`
+
+const testKVEntry_ttlUnsupported = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_kv_entry" "test" {
+  bucket = "PLAIN"
+  key    = "session"
+  value  = "x"
+  ttl    = "5s"
+}
+`
+
+func TestResourceKVEntryTTL(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	_, err = js.CreateKeyValue(ctx, jetstream.KeyValueConfig{Bucket: "PLAIN"})
+	checkErr(t, err, "could not create bucket: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testEntryDoesNotExist(ctx, t, js, "TEST", "session"),
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testKVEntry_ttlUnsupported, nc.ConnectedUrl()),
+				ExpectError: regexp.MustCompile(`bucket "PLAIN" as it does not have limit_marker_ttl set`),
+			},
+			{
+				Config: fmt.Sprintf(testKVEntry_ttl, nc.ConnectedUrl(), "one"),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "session", []byte("one")),
+					resource.TestCheckResourceAttr("jetstream_kv_entry.test", "ttl", "5s"),
+				),
+			},
+			{
+				// changing the value recreates the key so the ttl applies again
+				Config: fmt.Sprintf(testKVEntry_ttl, nc.ConnectedUrl(), "two"),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "session", []byte("two")),
+				),
+			},
+			{
+				// an expired key is created again
+				PreConfig: func() {
+					time.Sleep(6 * time.Second)
+					err := testEntryExist(ctx, t, js, "TEST", "session")(nil)
+					if err == nil {
+						t.Fatalf("expected the key to have expired")
+					}
+				},
+				Config: fmt.Sprintf(testKVEntry_ttl, nc.ConnectedUrl(), "two"),
+				Check: resource.ComposeTestCheckFunc(
+					testEntryHasValue(ctx, t, js, "TEST", "session", []byte("two")),
+				),
+			},
+		},
+	})
+}
+
+func TestKVEntryDestroyModes(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	pd := schema.TestResourceDataRaw(t, Provider().Schema, map[string]any{"servers": srv.ClientURL()})
+	m, err := connectMgr(pd)
+	checkErr(t, err, "configure failed: %v", err)
+	defer m.(*connection).close()
+
+	js, err := getJetStream(m)
+	checkErr(t, err, "connect failed: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	kv, err := js.CreateKeyValue(ctx, jetstream.KeyValueConfig{Bucket: "TEST", History: 5})
+	checkErr(t, err, "could not create bucket: %v", err)
+
+	history := func(key string) []jetstream.KeyValueEntry {
+		t.Helper()
+		entries, err := kv.History(ctx, key)
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return nil
+		}
+		checkErr(t, err, "could not load history: %v", err)
+		return entries
+	}
+
+	for _, key := range []string{"deleted", "purged"} {
+		_, err = kv.PutString(ctx, key, "one")
+		checkErr(t, err, "could not put: %v", err)
+		_, err = kv.PutString(ctx, key, "two")
+		checkErr(t, err, "could not put: %v", err)
+	}
+
+	// delete leaves the previous revisions and a delete marker
+	d := schema.TestResourceDataRaw(t, resourceKVEntry().Schema, map[string]any{"bucket": "TEST", "key": "deleted", "value": "two"})
+	diags := resourceKVEntryDelete(ctx, d, m)
+	if diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	if len(history("deleted")) != 3 {
+		t.Fatalf("expected 3 history entries got %d", len(history("deleted")))
+	}
+
+	// deleting again does not add another marker
+	diags = resourceKVEntryDelete(ctx, d, m)
+	if diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	if len(history("deleted")) != 3 {
+		t.Fatalf("expected 3 history entries got %d", len(history("deleted")))
+	}
+
+	// purge removes the history leaving only the purge marker
+	d = schema.TestResourceDataRaw(t, resourceKVEntry().Schema, map[string]any{"bucket": "TEST", "key": "purged", "value": "two", "destroy_mode": "purge"})
+	diags = resourceKVEntryDelete(ctx, d, m)
+	if diags.HasError() {
+		t.Fatalf("purge failed: %v", diags)
+	}
+	entries := history("purged")
+	if len(entries) != 1 || entries[0].Operation() != jetstream.KeyValuePurge {
+		t.Fatalf("expected only a purge marker got %d entries", len(entries))
+	}
+
+	// a missing bucket is not an error
+	err = js.DeleteKeyValue(ctx, "TEST")
+	checkErr(t, err, "could not delete bucket: %v", err)
+	diags = resourceKVEntryDelete(ctx, d, m)
+	if diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+}
@@ -17,23 +17,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 )
 
 func resourceObjBucket() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceObjBucketCreate,
-		Read:   resourceObjBucketRead,
-		Update: resourceObjBucketUpdate,
-		Delete: resourceObjBucketDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+	r := &schema.Resource{
+		CreateContext: resourceObjBucketCreate,
+		ReadContext:   resourceObjBucketRead,
+		UpdateContext: resourceObjBucketUpdate,
+		DeleteContext: resourceObjBucketDelete,
+		Importer:      importID("object store bucket", 1, legacyObjIdRegex),
+		Timeouts:      resourceTimeouts(),
+		SchemaVersion: 1,
+		CustomizeDiff: resourceObjBucketCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -57,20 +60,22 @@ func resourceObjBucket() *schema.Resource {
 				ValidateDiagFunc: validateStorageTypeString(),
 			},
 			"ttl": {
-				Type:         schema.TypeInt,
-				Description:  "How many seconds an object will be kept in the bucket",
-				Optional:     true,
-				ForceNew:     false,
-				Default:      0,
-				ValidateFunc: validation.IntAtLeast(0),
+				Type:             schema.TypeString,
+				Description:      "How long an object will be kept in the bucket, as a duration like 24h or a number of seconds",
+				Default:          "0",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateDuration(),
+				DiffSuppressFunc: suppressEquivalentDuration,
 			},
 			"max_bucket_size": {
-				Type:         schema.TypeInt,
-				Description:  "Maximum size of the entire bucket",
-				Default:      -1,
-				Optional:     true,
-				ForceNew:     false,
-				ValidateFunc: validation.IntAtLeast(-1),
+				Type:             schema.TypeString,
+				Description:      "Maximum size of the entire bucket, as a number of bytes or a size like 10GiB, -1 for unlimited",
+				Default:          "-1",
+				Optional:         true,
+				ForceNew:         false,
+				ValidateDiagFunc: validateByteSize(),
+				DiffSuppressFunc: suppressEquivalentByteSize,
 			},
 			"placement_cluster": {
 				Type:        schema.TypeString,
@@ -86,6 +91,17 @@ func resourceObjBucket() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"placement_preferred": {
+				Type:        schema.TypeString,
+				Description: "A preferred server name to move the bucket leader to when the bucket is created or this setting changes",
+				Default:     "",
+				Optional:    true,
+			},
+			"leader": {
+				Type:        schema.TypeString,
+				Description: "The name of the server currently leading the bucket",
+				Computed:    true,
+			},
 			"replicas": {
 				Type:         schema.TypeInt,
 				Description:  "Number of cluster replicas to store",
@@ -101,20 +117,87 @@ func resourceObjBucket() *schema.Resource {
 				ForceNew:    false,
 				Default:     false,
 			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Description: "Free form metadata about the bucket",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sealed": {
+				Type:        schema.TypeBool,
+				Description: "Seals the bucket so no objects can be added, changed or removed, a sealed bucket can not be unsealed",
+				Optional:    true,
+				Default:     false,
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Description: "The combined size of all objects and their metadata in bytes",
+				Computed:    true,
+			},
+			"object_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of objects in the bucket, including links and deleted objects",
+				Computed:    true,
+			},
+			"wait_for_healthy": {
+				Type:        schema.TypeBool,
+				Description: "Waits for the bucket to have an elected leader and all replicas current after creating or updating it",
+				Optional:    true,
+				Default:     false,
+			},
+			"healthy_max_lag": {
+				Type:         schema.TypeInt,
+				Description:  "The number of operations replicas may lag behind the leader and still be considered healthy when using wait_for_healthy",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 	}
+
+	unitAttributes := []string{"ttl", "max_bucket_size"}
+	r.StateUpgraders = stateUpgraders(r, unitAttributes, upgradeID("name"))
+
+	return r
 }
 
-func resourceObjBucketCreate(d *schema.ResourceData, m any) error {
-	nc, err := getConnection(d, m)
-	if err != nil {
-		return err
+// objBucketSettings are the attributes that update the bucket configuration, none can change once sealed
+var objBucketSettings = []string{"description", "ttl", "max_bucket_size", "placement_cluster", "placement_tags", "replicas", "compression", "metadata"}
+
+func resourceObjBucketCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("placement_preferred") {
+		err := d.SetNewComputed("leader")
+		if err != nil {
+			return err
+		}
+	}
+
+	old, _ := d.GetChange("sealed")
+	if !old.(bool) {
+		return nil
 	}
-	defer nc.Close()
 
+	if !d.Get("sealed").(bool) {
+		return fmt.Errorf("bucket %s is sealed and can not be unsealed", d.Get("name").(string))
+	}
+
+	for _, k := range objBucketSettings {
+		if d.HasChange(k) {
+			return fmt.Errorf("bucket %s is sealed, %s can not be changed", d.Get("name").(string), k)
+		}
+	}
+
+	return nil
+}
+
+func resourceObjBucketCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	name := d.Get("name").(string)
-	ttl := d.Get("ttl").(int)
-	maxB := d.Get("max_bucket_size").(int)
+	ttl := getDuration(d, "ttl")
+	maxB := getByteSize(d, "max_bucket_size")
 	replicas := d.Get("replicas").(int)
 	description := d.Get("description").(string)
 	compression := d.Get("compression").(bool)
@@ -142,80 +225,92 @@ func resourceObjBucketCreate(d *schema.ResourceData, m any) error {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	js, err := jetstream.New(nc)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	known, err := js.ObjectStore(ctx, name)
+	known, err := retryValue(ctx, func(ctx context.Context) (jetstream.ObjectStore, error) { return js.ObjectStore(ctx, name) })
 	if known != nil {
-		return fmt.Errorf("bucket %s already exist", name)
+		return diag.Errorf("bucket %s already exist", name)
 	} else if err != nil {
 		if !errors.Is(err, jetstream.ErrBucketNotFound) {
-			return fmt.Errorf("failed to load object store bucket: %s", err)
+			return diag.Errorf("failed to load object store bucket: %s", err)
 		}
 	}
 
-	_, err = js.CreateObjectStore(ctx, jetstream.ObjectStoreConfig{
+	cfg := jetstream.ObjectStoreConfig{
 		Bucket:      name,
 		Description: description,
-		TTL:         time.Duration(ttl) * time.Second,
-		MaxBytes:    int64(maxB),
+		TTL:         ttl,
+		MaxBytes:    maxB,
 		Storage:     storage,
 		Replicas:    replicas,
 		Placement:   placement,
 		Compression: compression,
-	})
-	if err != nil {
-		return err
+		Metadata:    objBucketMetadata(d),
 	}
 
-	d.SetId(fmt.Sprintf("JETSTREAM_OBJ_%s", name))
+	obj, err := retryValue(ctx, func(ctx context.Context) (jetstream.ObjectStore, error) { return js.CreateObjectStore(ctx, cfg) })
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	return resourceObjBucketRead(d, m)
-}
+	d.SetId(objBucketID(name))
 
-func resourceObjBucketRead(d *schema.ResourceData, m any) error {
-	name, err := parseStreamObjID(d.Id())
+	err = waitForResourceHealthy(ctx, d, m, "OBJ_"+name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	nc, err := getConnection(d, m)
-	if err != nil {
-		return err
+	diags := preferObjBucketLeader(ctx, d, m)
+
+	if d.Get("sealed").(bool) {
+		err = retry(ctx, func(ctx context.Context) error { return obj.Seal(ctx) })
+		if err != nil {
+			return append(diags, diag.Errorf("could not seal bucket: %s", err)...)
+		}
 	}
-	defer nc.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	return append(diags, resourceObjBucketRead(ctx, d, m)...)
+}
 
-	js, err := jetstream.New(nc)
+func resourceObjBucketRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	name, err := parseObjBucketID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	js, err := getJetStream(m)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	bucket, err := js.ObjectStore(ctx, name)
+	bucket, err := retryValue(ctx, func(ctx context.Context) (jetstream.ObjectStore, error) { return js.ObjectStore(ctx, name) })
 	if err != nil {
 		if errors.Is(err, jetstream.ErrBucketNotFound) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
-	status, err := bucket.Status(ctx)
+	status, err := retryValue(ctx, func(ctx context.Context) (jetstream.ObjectStoreStatus, error) { return bucket.Status(ctx) })
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", status.Bucket())
 	d.Set("description", status.Description())
-	d.Set("ttl", status.TTL().Seconds())
 	d.Set("replicas", status.Replicas())
 	d.Set("compression", status.IsCompressed())
+	d.Set("metadata", jsm.FilterServerMetadata(status.Metadata()))
+	d.Set("sealed", status.Sealed())
+	d.Set("size", int(status.Size()))
+
+	// the server clears the ttl when sealing, so the configured ttl is kept
+	if !status.Sealed() {
+		setDuration(d, "ttl", status.TTL())
+	}
 
 	switch status.Storage() {
 	case jetstream.FileStorage:
@@ -227,44 +322,74 @@ func resourceObjBucketRead(d *schema.ResourceData, m any) error {
 	oStatus := status.(*jetstream.ObjectBucketStatus)
 	si := oStatus.StreamInfo()
 
-	d.Set("max_bucket_size", si.Config.MaxBytes)
+	setByteSize(d, "max_bucket_size", si.Config.MaxBytes)
+
+	count, err := objBucketObjectCount(ctx, js, si.Config.Name, name)
+	if err != nil {
+		return diag.Errorf("could not count objects: %s", err)
+	}
+	d.Set("object_count", count)
 
 	if si.Config.Placement != nil {
 		d.Set("placement_cluster", si.Config.Placement.Cluster)
 		d.Set("placement_tags", si.Config.Placement.Tags)
 	}
 
+	if si.Cluster != nil {
+		d.Set("leader", si.Cluster.Leader)
+	}
+
 	return nil
 }
 
-func resourceObjBucketUpdate(d *schema.ResourceData, m any) error {
+func resourceObjBucketUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	name := d.Get("name").(string)
 
-	nc, err := getConnection(d, m)
+	js, err := getJetStream(m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	bucket, err := retryValue(ctx, func(ctx context.Context) (jetstream.ObjectStore, error) { return js.ObjectStore(ctx, name) })
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	defer nc.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	if d.HasChanges(objBucketSettings...) {
+		err = updateObjBucketConfig(ctx, d, m, js, bucket)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-	js, err := jetstream.New(nc)
-	if err != nil {
-		return err
+	var diags diag.Diagnostics
+	if d.HasChange("placement_preferred") {
+		diags = preferObjBucketLeader(ctx, d, m)
 	}
-	bucket, err := js.ObjectStore(ctx, name)
-	if err != nil {
-		return err
+
+	if d.HasChange("sealed") && d.Get("sealed").(bool) {
+		err = retry(ctx, func(ctx context.Context) error { return bucket.Seal(ctx) })
+		if err != nil {
+			return append(diags, diag.Errorf("could not seal bucket: %s", err)...)
+		}
 	}
-	status, err := bucket.Status(ctx)
+
+	return append(diags, resourceObjBucketRead(ctx, d, m)...)
+}
+
+// updateObjBucketConfig updates the bucket configuration, settings that are not managed are kept as they are on the server
+func updateObjBucketConfig(ctx context.Context, d *schema.ResourceData, m any, js jetstream.JetStream, bucket jetstream.ObjectStore) error {
+	name := d.Get("name").(string)
+
+	status, err := retryValue(ctx, func(ctx context.Context) (jetstream.ObjectStoreStatus, error) { return bucket.Status(ctx) })
 	if err != nil {
 		return err
 	}
 
 	oStatus := status.(*jetstream.ObjectBucketStatus)
 
-	str, err := js.Stream(ctx, oStatus.StreamInfo().Config.Name)
+	str, err := retryValue(ctx, func(ctx context.Context) (jetstream.Stream, error) {
+		return js.Stream(ctx, oStatus.StreamInfo().Config.Name)
+	})
 	if err != nil {
 		return err
 	}
@@ -277,11 +402,10 @@ func resourceObjBucketUpdate(d *schema.ResourceData, m any) error {
 		Storage:     str.CachedInfo().Config.Storage,
 		Replicas:    str.CachedInfo().Config.Replicas,
 		Compression: status.IsCompressed(),
-		Metadata:    str.CachedInfo().Config.Metadata,
 	}
 
-	ttl := d.Get("ttl").(int)
-	maxB := d.Get("max_bucket_size").(int)
+	ttl := getDuration(d, "ttl")
+	maxB := getByteSize(d, "max_bucket_size")
 	replicas := d.Get("replicas").(int)
 	description := d.Get("description").(string)
 	compression := d.Get("compression").(bool)
@@ -299,42 +423,127 @@ func resourceObjBucketUpdate(d *schema.ResourceData, m any) error {
 		}
 	}
 
-	cfg.TTL = time.Duration(ttl) * time.Second
-	cfg.MaxBytes = int64(maxB)
+	cfg.TTL = ttl
+	cfg.MaxBytes = maxB
 	cfg.Replicas = replicas
 	cfg.Description = description
 	cfg.Placement = placement
 	cfg.Compression = compression
+	cfg.Metadata = objBucketMetadata(d)
 
-	_, err = js.CreateOrUpdateObjectStore(ctx, cfg)
+	_, err = retryValue(ctx, func(ctx context.Context) (jetstream.ObjectStore, error) {
+		return js.CreateOrUpdateObjectStore(ctx, cfg)
+	})
 	if err != nil {
 		return err
 	}
 
-	return resourceObjBucketRead(d, m)
+	return waitForResourceHealthy(ctx, d, m, "OBJ_"+name)
 }
 
-func resourceObjBucketDelete(d *schema.ResourceData, m any) error {
-	name := d.Get("name").(string)
+// objBucketObjectCount counts the metadata subjects of the bucket rather than listing every object, each
+// object and link has one and deleted objects keep theirs as a delete marker
+func objBucketObjectCount(ctx context.Context, js jetstream.JetStream, stream string, name string) (int, error) {
+	str, err := retryValue(ctx, func(ctx context.Context) (jetstream.Stream, error) { return js.Stream(ctx, stream) })
+	if err != nil {
+		return 0, err
+	}
 
-	nc, err := getConnection(d, m)
+	info, err := retryValue(ctx, func(ctx context.Context) (*jetstream.StreamInfo, error) {
+		return str.Info(ctx, jetstream.WithSubjectFilter(fmt.Sprintf("$O.%s.M.>", name)))
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(info.State.Subjects), nil
+}
+
+func objBucketMetadata(d *schema.ResourceData) map[string]string {
+	meta := map[string]string{}
+	for k, v := range d.Get("metadata").(map[string]any) {
+		meta[k] = v.(string)
+	}
+
+	return jsm.FilterServerMetadata(meta)
+}
+
+// preferObjBucketLeader moves the leader to placement_preferred, failing to do so is a warning as an error
+// would replace a new bucket, the previous value is kept so the next apply tries again
+func preferObjBucketLeader(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	old, preferred := d.GetChange("placement_preferred")
+
+	err := moveObjBucketLeader(ctx, m, d.Get("name").(string), preferred.(string))
+	if err == nil {
+		return nil
+	}
+
+	d.Set("placement_preferred", old)
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Could not move the bucket leader to placement_preferred",
+		Detail:   err.Error(),
+	}}
+}
+
+// moveObjBucketLeader asks the leader of the bucket to step down in favour of the preferred server and
+// waits for it to be elected, preferred placement can not be stored in the bucket configuration
+func moveObjBucketLeader(ctx context.Context, m any, name string, preferred string) error {
+	if preferred == "" {
+		return nil
+	}
+
+	mgr, err := getManager(m)
+	if err != nil {
+		return err
+	}
+
+	str, err := retryValue(ctx, func(context.Context) (*jsm.Stream, error) { return mgr.LoadStream("OBJ_" + name) })
 	if err != nil {
 		return err
 	}
-	defer nc.Close()
 
-	js, err := jetstream.New(nc)
+	leader := func(context.Context) (string, error) {
+		ci, err := str.ClusterInfo()
+		return ci.Leader, err
+	}
+
+	current, err := retryValue(ctx, leader)
 	if err != nil {
 		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	if current == preferred {
+		return nil
+	}
+
+	err = str.LeaderStepDown(&api.Placement{Preferred: preferred})
+	if err != nil {
+		return fmt.Errorf("could not move the leader from %s to %s: %w", current, preferred, err)
+	}
+
+	return waitForHealthy(ctx, fmt.Sprintf("bucket %q", name), func() (bool, string, error) {
+		current, err := leader(ctx)
+		if err != nil {
+			return false, "", err
+		}
 
-	err = js.DeleteObjectStore(ctx, name)
+		return current == preferred, fmt.Sprintf("the leader is %s rather than %s", current, preferred), nil
+	})
+}
+
+func resourceObjBucketDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	name := d.Get("name").(string)
+
+	js, err := getJetStream(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = retry(ctx, func(ctx context.Context) error { return js.DeleteObjectStore(ctx, name) })
 	if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
 		return nil
 	} else if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
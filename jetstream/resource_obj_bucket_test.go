@@ -16,6 +16,7 @@ package jetstream
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -149,3 +150,152 @@ func TestResourceObjExternalDeletion(t *testing.T) {
 		},
 	})
 }
+
+const testObj_sealed = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_obj_bucket" "test" {
+  name = "TEST"
+  ttl = "%s"
+  sealed = %t
+
+  metadata = {
+    owner = "%s"
+  }
+}
+`
+
+func TestResourceObjBucketSealed(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	config := func(ttl string, sealed bool, owner string) string {
+		return fmt.Sprintf(testObj_sealed, nc.ConnectedUrl(), ttl, sealed, owner)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testObjBucketDoesNotExist(t, mgr, "TEST"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("1h", false, "ops"),
+				Check: resource.ComposeTestCheckFunc(
+					testObjBucketExist(t, mgr, "TEST"),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "metadata.%", "1"),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "metadata.owner", "ops"),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "sealed", "false"),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "object_count", "0"),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "size", "0"),
+				),
+			},
+			{
+				Config: config("1h", false, "dev"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "metadata.owner", "dev"),
+				),
+			},
+			{
+				PreConfig: func() {
+					obj, err := js.ObjectStore(ctx, "TEST")
+					checkErr(t, err, "could not load bucket: %v", err)
+					_, err = obj.PutString(ctx, "hello", "world")
+					checkErr(t, err, "could not put: %v", err)
+				},
+				Config: config("1h", true, "dev"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "sealed", "true"),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "ttl", "1h"),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "object_count", "1"),
+					resource.TestCheckResourceAttrWith("jetstream_obj_bucket.test", "size", func(v string) error {
+						if v == "0" {
+							return fmt.Errorf("expected a size for a bucket with objects")
+						}
+						return nil
+					}),
+					func(_ *terraform.State) error {
+						obj, err := js.ObjectStore(ctx, "TEST")
+						if err != nil {
+							return err
+						}
+						_, err = obj.PutString(ctx, "other", "world")
+						if err == nil {
+							return fmt.Errorf("expected put into a sealed bucket to fail")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      config("1h", false, "dev"),
+				ExpectError: regexp.MustCompile("can not be unsealed"),
+			},
+			{
+				Config:      config("1h", true, "ops"),
+				ExpectError: regexp.MustCompile("metadata can not be changed"),
+			},
+		},
+	})
+}
+
+const testObj_preferred = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_obj_bucket" "test" {
+  name = "TEST"
+  placement_preferred = "%s"
+}
+`
+
+func TestResourceObjBucketPreferredLeader(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testObjBucketDoesNotExist(t, mgr, "TEST"),
+		Steps: []resource.TestStep{
+			{
+				// the only server is already the leader
+				Config: fmt.Sprintf(testObj_preferred, nc.ConnectedUrl(), srv.Name()),
+				Check: resource.ComposeTestCheckFunc(
+					testObjBucketExist(t, mgr, "TEST"),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "placement_preferred", srv.Name()),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "leader", srv.Name()),
+				),
+			},
+			{
+				// the move fails with a warning and the configured server is not recorded so it is tried again
+				Config: fmt.Sprintf(testObj_preferred, nc.ConnectedUrl(), "other"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "placement_preferred", srv.Name()),
+					resource.TestCheckResourceAttr("jetstream_obj_bucket.test", "leader", srv.Name()),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,312 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func resourceObjDirectory() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObjDirectoryCreate,
+		ReadContext:   resourceObjDirectoryRead,
+		UpdateContext: resourceObjDirectoryUpdate,
+		DeleteContext: resourceObjDirectoryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceObjDirectoryImport,
+		},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: resourceObjDirectoryCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Description:  "The name of the Object Store bucket",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"source": {
+				Type:         schema.TypeString,
+				Description:  "The path to the local directory to upload",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "A prefix added to the name of every object, only objects with this prefix are managed",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"objects": {
+				Type:        schema.TypeMap,
+				Description: "The SHA-256 digest of each object, by name without the prefix",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceObjDirectoryCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.NewValueKnown("source") {
+		d.SetNewComputed("objects")
+		return nil
+	}
+
+	// the local digests become the planned objects, so any new, changed or removed file and any
+	// object changed outside of terraform shows up as a change
+	local, err := localObjDigests(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	stored := d.Get("objects").(map[string]any)
+	if objDigestsEqual(stored, local) {
+		return nil
+	}
+
+	return d.SetNew("objects", local)
+}
+
+func resourceObjDirectoryCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+
+	err := syncObjDirectory(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(prefixID(bucket, prefix))
+
+	return resourceObjDirectoryRead(ctx, d, m)
+}
+
+// resourceObjDirectoryImport adopts the objects with the prefix, the source is not known until it is configured
+func resourceObjDirectoryImport(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	bucket, prefix, err := splitPrefixID(d.Id(), "obj directory")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("bucket", bucket)
+	d.Set("prefix", prefix)
+	d.SetId(prefixID(bucket, prefix))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceObjDirectoryRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	obj, err := objStore(ctx, m, d.Get("bucket").(string))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	stored, err := storedObjDigests(ctx, obj, d.Get("prefix").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("objects", stored)
+
+	return nil
+}
+
+func resourceObjDirectoryUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	err := syncObjDirectory(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceObjDirectoryRead(ctx, d, m)
+}
+
+func resourceObjDirectoryDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	prefix := d.Get("prefix").(string)
+
+	obj, err := objStore(ctx, m, d.Get("bucket").(string))
+	if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for name := range d.Get("objects").(map[string]any) {
+		err = retry(ctx, func(ctx context.Context) error { return obj.Delete(ctx, prefix+name) })
+		if err != nil && !errors.Is(err, jetstream.ErrObjectNotFound) {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// syncObjDirectory uploads files whose digest differs from the stored object and deletes objects
+// with the prefix that are not in the directory
+func syncObjDirectory(ctx context.Context, d *schema.ResourceData, m any) error {
+	source := d.Get("source").(string)
+	prefix := d.Get("prefix").(string)
+
+	obj, err := objStore(ctx, m, d.Get("bucket").(string))
+	if err != nil {
+		return err
+	}
+
+	local, err := localObjDigests(source)
+	if err != nil {
+		return err
+	}
+
+	// compared with the bucket rather than the state so nothing is uploaded twice when an earlier apply failed part way
+	stored, err := storedObjDigests(ctx, obj, prefix)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(local))
+	for name := range local {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if stored[name] == local[name] {
+			continue
+		}
+
+		// uploads can take longer than a single request so use the operation context
+		path := filepath.Join(source, filepath.FromSlash(name))
+		err = retry(ctx, func(context.Context) error {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			_, err = obj.Put(ctx, jetstream.ObjectMeta{Name: prefix + name}, f)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("could not upload %q: %w", path, err)
+		}
+	}
+
+	for name := range stored {
+		if _, ok := local[name]; ok {
+			continue
+		}
+
+		err = retry(ctx, func(ctx context.Context) error { return obj.Delete(ctx, prefix+name) })
+		if err != nil && !errors.Is(err, jetstream.ErrObjectNotFound) {
+			return fmt.Errorf("could not delete object %q: %w", prefix+name, err)
+		}
+	}
+
+	return nil
+}
+
+// localObjDigests computes the digest of every regular file below dir by its slash separated relative path
+func localObjDigests(dir string) (map[string]string, error) {
+	digests := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		digest, _, err := readObjDigest(f)
+		if err != nil {
+			return err
+		}
+
+		digests[filepath.ToSlash(rel)] = digest
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read source directory: %w", err)
+	}
+
+	return digests, nil
+}
+
+// storedObjDigests lists the digests of objects with prefix by their name without the prefix, links are not included
+func storedObjDigests(ctx context.Context, obj jetstream.ObjectStore, prefix string) (map[string]string, error) {
+	infos, err := retryValue(ctx, func(context.Context) ([]*jetstream.ObjectInfo, error) { return obj.List(ctx) })
+	if errors.Is(err, jetstream.ErrNoObjectsFound) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	digests := map[string]string{}
+	for _, info := range infos {
+		if info.Opts != nil && info.Opts.Link != nil {
+			continue
+		}
+		if !strings.HasPrefix(info.Name, prefix) || info.Name == prefix {
+			continue
+		}
+
+		digests[strings.TrimPrefix(info.Name, prefix)] = info.Digest
+	}
+
+	return digests, nil
+}
+
+func objDigestsEqual(stored map[string]any, local map[string]string) bool {
+	if len(stored) != len(local) {
+		return false
+	}
+
+	for name, digest := range local {
+		if stored[name] != digest {
+			return false
+		}
+	}
+
+	return true
+}
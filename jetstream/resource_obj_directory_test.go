@@ -0,0 +1,137 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const testObjDirectory_basic = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_obj_bucket" "test" {
+  name = "TEST"
+}
+
+resource "jetstream_obj_directory" "test" {
+  bucket = jetstream_obj_bucket.test.name
+  source = "%s"
+  prefix = "site/"
+}
+`
+
+func TestResourceObjDirectory(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	write := func(name string, content string) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(path), 0700)
+		checkErr(t, err, "could not create directory: %v", err)
+		err = os.WriteFile(path, []byte(content), 0600)
+		checkErr(t, err, "could not write file: %v", err)
+	}
+	put := func(name string, content string) {
+		obj, err := js.ObjectStore(ctx, "TEST")
+		checkErr(t, err, "could not load bucket: %v", err)
+		_, err = obj.PutString(ctx, name, content)
+		checkErr(t, err, "could not put: %v", err)
+	}
+
+	write("index.html", "<html></html>")
+	write("css/site.css", "body {}")
+
+	config := fmt.Sprintf(testObjDirectory_basic, nc.ConnectedUrl(), dir)
+
+	var nuid string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testObjectDoesNotExist(ctx, js, "TEST", "site/index.html"),
+			testObjectDoesNotExist(ctx, js, "TEST", "site/js/site.js"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testObjectHasContent(ctx, js, "TEST", "site/index.html", []byte("<html></html>")),
+					testObjectHasContent(ctx, js, "TEST", "site/css/site.css", []byte("body {}")),
+					testObjectNUID(ctx, js, "TEST", "site/index.html", &nuid),
+					resource.TestCheckResourceAttr("jetstream_obj_directory.test", "objects.%", "2"),
+					resource.TestCheckResourceAttr("jetstream_obj_directory.test", "objects.index.html", objDigestOf([]byte("<html></html>"))),
+				),
+			},
+			{
+				// only changed files are uploaded and removed files are deleted
+				PreConfig: func() {
+					write("js/site.js", "main()")
+					err := os.Remove(filepath.Join(dir, "css", "site.css"))
+					checkErr(t, err, "could not remove file: %v", err)
+					put("other", "kept")
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testObjectNUIDUnchanged(ctx, js, "TEST", "site/index.html", &nuid),
+					testObjectHasContent(ctx, js, "TEST", "site/js/site.js", []byte("main()")),
+					testObjectDoesNotExist(ctx, js, "TEST", "site/css/site.css"),
+					testObjectHasContent(ctx, js, "TEST", "other", []byte("kept")),
+					resource.TestCheckResourceAttr("jetstream_obj_directory.test", "objects.%", "2"),
+				),
+			},
+			{
+				// objects changed or added outside of terraform are restored and removed
+				PreConfig: func() {
+					put("site/index.html", "changed")
+					put("site/extra.html", "extra")
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testObjectHasContent(ctx, js, "TEST", "site/index.html", []byte("<html></html>")),
+					testObjectDoesNotExist(ctx, js, "TEST", "site/extra.html"),
+					resource.TestCheckResourceAttr("jetstream_obj_directory.test", "objects.%", "2"),
+				),
+			},
+			{
+				ResourceName:            "jetstream_obj_directory.test",
+				ImportState:             true,
+				ImportStateId:           "TEST/site/",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source", "timeouts"},
+			},
+		},
+	})
+}
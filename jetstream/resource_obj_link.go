@@ -0,0 +1,155 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func resourceObjLink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObjLinkCreate,
+		ReadContext:   resourceObjLinkRead,
+		DeleteContext: resourceObjLinkDelete,
+		Importer:      importID("object link", 2, nil),
+		Timeouts:      resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Description:  "The name of the Object Store bucket holding the link",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the link",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"target_bucket": {
+				Type:         schema.TypeString,
+				Description:  "The bucket being linked to, this can be the bucket holding the link",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"target_object": {
+				Type:        schema.TypeString,
+				Description: "The object being linked to, when not set the link is to the whole target_bucket",
+				Optional:    true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+func resourceObjLinkCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	name := d.Get("name").(string)
+	targetObject := d.Get("target_object").(string)
+
+	obj, err := objStore(ctx, m, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	target, err := objStore(ctx, m, d.Get("target_bucket").(string))
+	if err != nil {
+		return diag.Errorf("could not load target_bucket: %s", err)
+	}
+
+	if targetObject == "" {
+		_, err = retryValue(ctx, func(ctx context.Context) (*jetstream.ObjectInfo, error) { return obj.AddBucketLink(ctx, name, target) })
+	} else {
+		var info *jetstream.ObjectInfo
+		info, err = retryValue(ctx, func(ctx context.Context) (*jetstream.ObjectInfo, error) { return target.GetInfo(ctx, targetObject) })
+		if err != nil {
+			return diag.Errorf("could not load target_object: %s", err)
+		}
+
+		_, err = retryValue(ctx, func(ctx context.Context) (*jetstream.ObjectInfo, error) { return obj.AddLink(ctx, name, info) })
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(joinID(bucket, name))
+
+	return resourceObjLinkRead(ctx, d, m)
+}
+
+func resourceObjLinkRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket, name, err := parseObjObjectID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	obj, err := objStore(ctx, m, bucket)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrBucketNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	info, err := retryValue(ctx, func(ctx context.Context) (*jetstream.ObjectInfo, error) { return obj.GetInfo(ctx, name) })
+	if err != nil {
+		if errors.Is(err, jetstream.ErrObjectNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if info.Opts == nil || info.Opts.Link == nil {
+		return diag.Errorf("object %q in bucket %q is not a link", name, bucket)
+	}
+
+	d.Set("bucket", bucket)
+	d.Set("name", info.Name)
+	d.Set("target_bucket", info.Opts.Link.Bucket)
+	d.Set("target_object", info.Opts.Link.Name)
+
+	return nil
+}
+
+func resourceObjLinkDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	obj, err := objStore(ctx, m, d.Get("bucket").(string))
+	if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// deleting a link leaves the object or bucket it links to in place
+	err = retry(ctx, func(ctx context.Context) error { return obj.Delete(ctx, d.Get("name").(string)) })
+	if errors.Is(err, jetstream.ErrObjectNotFound) {
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
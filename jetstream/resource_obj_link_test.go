@@ -0,0 +1,127 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const testObjLink_basic = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_obj_bucket" "models" {
+  name = "MODELS"
+}
+
+resource "jetstream_obj_bucket" "releases" {
+  name = "RELEASES"
+}
+
+resource "jetstream_obj_object" "model" {
+  bucket  = jetstream_obj_bucket.models.name
+  name    = "%s"
+  content = "model"
+}
+
+resource "jetstream_obj_link" "latest" {
+  bucket        = jetstream_obj_bucket.releases.name
+  name          = "latest"
+  target_bucket = jetstream_obj_object.model.bucket
+  target_object = jetstream_obj_object.model.name
+}
+
+resource "jetstream_obj_link" "models" {
+  bucket        = jetstream_obj_bucket.releases.name
+  name          = "models"
+  target_bucket = jetstream_obj_bucket.models.name
+}
+`
+
+func TestResourceObjLink(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testObjectDoesNotExist(ctx, js, "RELEASES", "latest"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testObjLink_basic, nc.ConnectedUrl(), "v1.bin"),
+				Check: resource.ComposeTestCheckFunc(
+					testObjectLinksTo(ctx, js, "RELEASES", "latest", "MODELS", "v1.bin"),
+					testObjectLinksTo(ctx, js, "RELEASES", "models", "MODELS", ""),
+					testObjectHasContent(ctx, js, "RELEASES", "latest", []byte("model")),
+					resource.TestCheckResourceAttr("jetstream_obj_link.latest", "id", "RELEASES/latest"),
+					resource.TestCheckResourceAttr("jetstream_obj_link.models", "target_object", ""),
+				),
+			},
+			{
+				// changing the target replaces the link
+				Config: fmt.Sprintf(testObjLink_basic, nc.ConnectedUrl(), "v2.bin"),
+				Check: resource.ComposeTestCheckFunc(
+					testObjectLinksTo(ctx, js, "RELEASES", "latest", "MODELS", "v2.bin"),
+				),
+			},
+			{
+				ResourceName:      "jetstream_obj_link.latest",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testObjectLinksTo(ctx context.Context, js jetstream.JetStream, bucket string, name string, targetBucket string, targetObject string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj, err := js.ObjectStore(ctx, bucket)
+		if err != nil {
+			return err
+		}
+
+		info, err := obj.GetInfo(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if info.Opts == nil || info.Opts.Link == nil {
+			return fmt.Errorf("expected %q to be a link", name)
+		}
+
+		if info.Opts.Link.Bucket != targetBucket || info.Opts.Link.Name != targetObject {
+			return fmt.Errorf("expected %q to link to %s/%s got %s/%s", name, targetBucket, targetObject, info.Opts.Link.Bucket, info.Opts.Link.Name)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+var objObjectContentAttributes = []string{"content", "content_base64", "content_file"}
+
+func resourceObjObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObjObjectCreate,
+		ReadContext:   resourceObjObjectRead,
+		UpdateContext: resourceObjObjectUpdate,
+		DeleteContext: resourceObjObjectDelete,
+		Importer:      importID("object", 2, nil),
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: resourceObjObjectCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Description:  "The name of the Object Store bucket",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the object",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Contains additional information about this object",
+				Optional:    true,
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Description: "Headers stored with the object",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Description: "Free form metadata about the object",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"content": {
+				Type:         schema.TypeString,
+				Description:  "The object content as a string",
+				Optional:     true,
+				ExactlyOneOf: objObjectContentAttributes,
+			},
+			"content_base64": {
+				Type:         schema.TypeString,
+				Description:  "The object content encoded as base64, for binary content",
+				Optional:     true,
+				ExactlyOneOf: objObjectContentAttributes,
+				ValidateFunc: validation.StringIsBase64,
+			},
+			"content_file": {
+				Type:         schema.TypeString,
+				Description:  "The path to a file holding the object content",
+				Optional:     true,
+				ExactlyOneOf: objObjectContentAttributes,
+			},
+			"digest": {
+				Type:        schema.TypeString,
+				Description: "The SHA-256 digest of the stored object",
+				Computed:    true,
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Description: "The size of the stored object in bytes",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceObjObjectCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	for _, attr := range objObjectContentAttributes {
+		if !d.NewValueKnown(attr) {
+			d.SetNewComputed("digest")
+			d.SetNewComputed("size")
+			return nil
+		}
+	}
+
+	// the content is only uploaded when its digest differs from the stored object, which also
+	// detects changes to the content of files and to objects changed outside of terraform
+	digest, size, err := objContentDigest(d.Get)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("digest").(string) != digest {
+		err = d.SetNew("digest", digest)
+		if err != nil {
+			return err
+		}
+		err = d.SetNew("size", int(size))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceObjObjectCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+	name := d.Get("name").(string)
+
+	obj, err := objStore(ctx, m, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = putObjObject(ctx, obj, objObjectMeta(d), d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(joinID(bucket, name))
+
+	return resourceObjObjectRead(ctx, d, m)
+}
+
+func resourceObjObjectRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	bucket, name, err := parseObjObjectID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	obj, err := objStore(ctx, m, bucket)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	info, err := retryValue(ctx, func(ctx context.Context) (*jetstream.ObjectInfo, error) { return obj.GetInfo(ctx, name) })
+	if err != nil {
+		if errors.Is(err, jetstream.ErrObjectNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("bucket", bucket)
+	d.Set("name", info.Name)
+	d.Set("description", info.Description)
+	d.Set("headers", objHeadersMap(info.Headers))
+	d.Set("metadata", info.Metadata)
+	d.Set("digest", info.Digest)
+	d.Set("size", int(info.Size))
+
+	return nil
+}
+
+func resourceObjObjectUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	obj, err := objStore(ctx, m, d.Get("bucket").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	meta := objObjectMeta(d)
+
+	// changing only the description, headers or metadata does not upload the content again
+	if d.HasChange("digest") {
+		err = putObjObject(ctx, obj, meta, d.Get)
+	} else {
+		err = retry(ctx, func(ctx context.Context) error { return obj.UpdateMeta(ctx, meta.Name, meta) })
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceObjObjectRead(ctx, d, m)
+}
+
+func resourceObjObjectDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	obj, err := objStore(ctx, m, d.Get("bucket").(string))
+	if errors.Is(err, jetstream.ErrBucketNotFound) || errors.Is(err, nats.ErrStreamNotFound) {
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = retry(ctx, func(ctx context.Context) error { return obj.Delete(ctx, d.Get("name").(string)) })
+	if errors.Is(err, jetstream.ErrObjectNotFound) {
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func parseObjObjectID(id string) (bucket string, name string, err error) {
+	names, err := splitID(id, 2, "object")
+	if err != nil {
+		return "", "", err
+	}
+
+	return names[0], names[1], nil
+}
+
+func objStore(ctx context.Context, m any, bucket string) (jetstream.ObjectStore, error) {
+	js, err := getJetStream(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return retryValue(ctx, func(ctx context.Context) (jetstream.ObjectStore, error) { return js.ObjectStore(ctx, bucket) })
+}
+
+func objObjectMeta(d *schema.ResourceData) jetstream.ObjectMeta {
+	meta := jetstream.ObjectMeta{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+
+	headers := d.Get("headers").(map[string]any)
+	if len(headers) > 0 {
+		meta.Headers = nats.Header{}
+		for k, v := range headers {
+			meta.Headers.Set(k, v.(string))
+		}
+	}
+
+	metadata := d.Get("metadata").(map[string]any)
+	if len(metadata) > 0 {
+		meta.Metadata = map[string]string{}
+		for k, v := range metadata {
+			meta.Metadata[k] = v.(string)
+		}
+	}
+
+	return meta
+}
+
+// objHeadersMap converts headers to a map, multiple values for a header are joined by commas
+func objHeadersMap(headers nats.Header) map[string]string {
+	res := make(map[string]string, len(headers))
+	for k, v := range headers {
+		res[k] = strings.Join(v, ",")
+	}
+
+	return res
+}
+
+// openObjContent opens the configured content, get is the Get of a ResourceData or ResourceDiff
+func openObjContent(get func(string) any) (io.ReadCloser, error) {
+	if path := get("content_file").(string); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read content_file: %w", err)
+		}
+		return f, nil
+	}
+
+	if encoded := get("content_base64").(string); encoded != "" {
+		content, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content_base64: %w", err)
+		}
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+
+	return io.NopCloser(strings.NewReader(get("content").(string))), nil
+}
+
+// objContentDigest computes the digest of the configured content the same way the object store does
+func objContentDigest(get func(string) any) (string, int64, error) {
+	r, err := openObjContent(get)
+	if err != nil {
+		return "", 0, err
+	}
+	defer r.Close()
+
+	return readObjDigest(r)
+}
+
+// readObjDigest computes the digest and size of everything read from r
+func readObjDigest(r io.Reader) (string, int64, error) {
+	h := sha256.New()
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return objDigest(h.Sum(nil)), size, nil
+}
+
+func objDigest(sum []byte) string {
+	return "SHA-256=" + base64.URLEncoding.EncodeToString(sum)
+}
+
+// putObjObject uploads the configured content, files are streamed rather than read into memory and the
+// upload is bounded by the operation timeout as it can take longer than a single request
+func putObjObject(ctx context.Context, obj jetstream.ObjectStore, meta jetstream.ObjectMeta, get func(string) any) error {
+	return retry(ctx, func(context.Context) error {
+		r, err := openObjContent(get)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		_, err = obj.Put(ctx, meta, r)
+		return err
+	})
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const testObjObject_basic = `
+provider "jetstream" {
+  servers = "%s"
+}
+
+resource "jetstream_obj_bucket" "test" {
+  name = "TEST"
+}
+
+resource "jetstream_obj_object" "test" {
+  bucket      = jetstream_obj_bucket.test.name
+  name        = "models/model.bin"
+  description = "%s"
+  headers     = { "Content-Type" = "text/plain" }
+  metadata    = { "version" = "1" }
+  %s
+}
+`
+
+func TestResourceObjObject(t *testing.T) {
+	srv := createJSServer(t)
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	checkErr(t, err, "could not connect: %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	file := filepath.Join(t.TempDir(), "model.bin")
+	err = os.WriteFile(file, []byte("model one"), 0600)
+	checkErr(t, err, "could not write file: %v", err)
+
+	config := func(description string, content string) string {
+		return fmt.Sprintf(testObjObject_basic, nc.ConnectedUrl(), description, content)
+	}
+
+	var nuid string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testJsProviders,
+		CheckDestroy:      testObjectDoesNotExist(ctx, js, "TEST", "models/model.bin"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("a model", `content = "hello world"`),
+				Check: resource.ComposeTestCheckFunc(
+					testObjectHasContent(ctx, js, "TEST", "models/model.bin", []byte("hello world")),
+					testObjectNUID(ctx, js, "TEST", "models/model.bin", &nuid),
+					resource.TestCheckResourceAttr("jetstream_obj_object.test", "id", "TEST/models%2Fmodel.bin"),
+					resource.TestCheckResourceAttr("jetstream_obj_object.test", "size", "11"),
+					resource.TestCheckResourceAttr("jetstream_obj_object.test", "digest", objDigestOf([]byte("hello world"))),
+					resource.TestCheckResourceAttr("jetstream_obj_object.test", "headers.Content-Type", "text/plain"),
+					resource.TestCheckResourceAttr("jetstream_obj_object.test", "metadata.version", "1"),
+				),
+			},
+			{
+				// changing only the description does not upload the object again
+				Config: config("the model", `content = "hello world"`),
+				Check: resource.ComposeTestCheckFunc(
+					testObjectNUIDUnchanged(ctx, js, "TEST", "models/model.bin", &nuid),
+					resource.TestCheckResourceAttr("jetstream_obj_object.test", "description", "the model"),
+				),
+			},
+			{
+				// switching to identical content from another source does not upload it again
+				Config: config("the model", fmt.Sprintf(`content_base64 = "%s"`, "aGVsbG8gd29ybGQ=")),
+				Check: resource.ComposeTestCheckFunc(
+					testObjectNUIDUnchanged(ctx, js, "TEST", "models/model.bin", &nuid),
+				),
+			},
+			{
+				Config: config("the model", fmt.Sprintf(`content_file = "%s"`, file)),
+				Check: resource.ComposeTestCheckFunc(
+					testObjectHasContent(ctx, js, "TEST", "models/model.bin", []byte("model one")),
+					resource.TestCheckResourceAttr("jetstream_obj_object.test", "size", "9"),
+				),
+			},
+			{
+				// changes to the file content are uploaded
+				PreConfig: func() {
+					err := os.WriteFile(file, []byte("model two!"), 0600)
+					checkErr(t, err, "could not write file: %v", err)
+				},
+				Config: config("the model", fmt.Sprintf(`content_file = "%s"`, file)),
+				Check: resource.ComposeTestCheckFunc(
+					testObjectHasContent(ctx, js, "TEST", "models/model.bin", []byte("model two!")),
+					resource.TestCheckResourceAttr("jetstream_obj_object.test", "size", "10"),
+				),
+			},
+			{
+				// objects changed outside of terraform are uploaded again
+				PreConfig: func() {
+					obj, err := js.ObjectStore(ctx, "TEST")
+					checkErr(t, err, "could not load bucket: %v", err)
+					_, err = obj.PutString(ctx, "models/model.bin", "changed")
+					checkErr(t, err, "could not put: %v", err)
+				},
+				Config: config("the model", fmt.Sprintf(`content_file = "%s"`, file)),
+				Check: resource.ComposeTestCheckFunc(
+					testObjectHasContent(ctx, js, "TEST", "models/model.bin", []byte("model two!")),
+					resource.TestCheckResourceAttr("jetstream_obj_object.test", "description", "the model"),
+				),
+			},
+		},
+	})
+}
+
+func objDigestOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return objDigest(sum[:])
+}
+
+func testObjectHasContent(ctx context.Context, js jetstream.JetStream, bucket string, name string, content []byte) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj, err := js.ObjectStore(ctx, bucket)
+		if err != nil {
+			return err
+		}
+
+		stored, err := obj.GetBytes(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(stored, content) {
+			return fmt.Errorf("expected object %q to hold %q got %q", name, content, stored)
+		}
+
+		return nil
+	}
+}
+
+func testObjectDoesNotExist(ctx context.Context, js jetstream.JetStream, bucket string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj, err := js.ObjectStore(ctx, bucket)
+		if err != nil {
+			return nil
+		}
+
+		_, err = obj.GetInfo(ctx, name)
+		if err == nil {
+			return fmt.Errorf("expected object %q in bucket %q to not exist", name, bucket)
+		}
+
+		return nil
+	}
+}
+
+func testObjectNUID(ctx context.Context, js jetstream.JetStream, bucket string, name string, nuid *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj, err := js.ObjectStore(ctx, bucket)
+		if err != nil {
+			return err
+		}
+
+		info, err := obj.GetInfo(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		*nuid = info.NUID
+
+		return nil
+	}
+}
+
+// testObjectNUIDUnchanged checks the object was not uploaded again, every upload gets a new nuid
+func testObjectNUIDUnchanged(ctx context.Context, js jetstream.JetStream, bucket string, name string, nuid *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		previous := *nuid
+
+		err := testObjectNUID(ctx, js, bucket, name, nuid)(s)
+		if err != nil {
+			return err
+		}
+
+		if *nuid != previous {
+			return fmt.Errorf("expected object %q to not be uploaded again", name)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	retryInitialBackoff = 250 * time.Millisecond
+	retryMaxBackoff     = 5 * time.Second
+
+	// defaultRequestTimeout bounds each attempt when the provider request_timeout is not set
+	defaultRequestTimeout = 5 * time.Second
+)
+
+type requestTimeoutKey struct{}
+
+// server error codes that are returned while a cluster is electing leaders or peers are restarting
+var transientErrorCodes = []uint16{
+	10006, // JSClusterNotActiveErr
+	10008, // JSClusterNotAvailErr, JetStream system temporarily unavailable
+	10009, // JSClusterNotLeaderErr
+	10118, // JSStreamOfflineErr
+	10119, // JSConsumerOfflineErr
+}
+
+// withRequestTimeout records the request_timeout of the provider m on ctx so every attempt made by
+// the retry helpers is bounded by it rather than only by the timeout of the whole operation
+func withRequestTimeout(ctx context.Context, m any) context.Context {
+	c, ok := m.(*connection)
+	if !ok {
+		return ctx
+	}
+
+	timeout := c.requestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// requestContext is the context for a single attempt, bounded by the request timeout when one is set on ctx
+func requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration)
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// retry calls fn until it succeeds, fails with an error that is not transient or ctx is done, backing
+// off exponentially between attempts. Each attempt gets a context bounded by the request timeout,
+// calls that stream data like object uploads should use the outer context instead. Only idempotent
+// calls should be retried this way as a call that timed out might still have been applied by the server.
+func retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	_, err := retryValue(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+
+	return err
+}
+
+// retryValue is retry for calls that return a value
+func retryValue[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) (T, error) {
+	return retryIf(ctx, isTransientError, fn)
+}
+
+// retryUnapplied retries fn only on errors where the server certainly did not apply the
+// request, suitable for calls that are not idempotent like KV puts
+func retryUnapplied(ctx context.Context, fn func(ctx context.Context) error) error {
+	_, err := retryIf(ctx, isUnappliedError, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+
+	return err
+}
+
+func retryIf[T any](ctx context.Context, retryable func(error) bool, fn func(ctx context.Context) (T, error)) (T, error) {
+	backoff := retryInitialBackoff
+
+	for {
+		attempt, cancel := requestContext(ctx)
+		res, err := fn(attempt)
+		cancel()
+		if err == nil || !retryable(err) || ctx.Err() != nil {
+			return res, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, err
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > retryMaxBackoff {
+			backoff = retryMaxBackoff
+		}
+	}
+}
+
+// isUnappliedError is true for transient errors where the request never reached JetStream or was
+// rejected by the server before being processed
+func isUnappliedError(err error) bool {
+	if errors.Is(err, nats.ErrNoResponders) {
+		return true
+	}
+
+	if api.IsNatsErr(err, transientErrorCodes...) {
+		return true
+	}
+
+	var apiErr *jetstream.APIError
+	if errors.As(err, &apiErr) {
+		for _, code := range transientErrorCodes {
+			if uint16(apiErr.ErrorCode) == code {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isTransientError is true for errors that might resolve by themselves such as timeouts or
+// leader elections, validation and other user errors are never transient
+func isTransientError(err error) bool {
+	if isUnappliedError(err) {
+		return true
+	}
+
+	return errors.Is(err, nats.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, nats.ErrConnectionReconnecting)
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func TestRetry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	transient := []error{
+		nats.ErrNoResponders,
+		nats.ErrTimeout,
+		api.ApiError{Code: 503, ErrCode: 10008, Description: "JetStream system temporarily unavailable"},
+		fmt.Errorf("wrapped: %w", &api.ApiError{Code: 500, ErrCode: 10009}),
+		&jetstream.APIError{Code: 500, ErrorCode: 10009},
+	}
+
+	for _, terr := range transient {
+		attempts := 0
+		res, err := retryValue(ctx, func(ctx context.Context) (int, error) {
+			attempts++
+			if attempts < 3 {
+				return 0, terr
+			}
+			return attempts, nil
+		})
+		checkErr(t, err, "retry failed for %v: %v", terr, err)
+		if res != 3 {
+			t.Fatalf("expected 3 attempts for %v, got %d", terr, res)
+		}
+	}
+}
+
+func TestRetryPermanentErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	permanent := []error{
+		errors.New("invalid stream config"),
+		api.ApiError{Code: 400, ErrCode: 10058, Description: "stream name already in use"},
+		jetstream.ErrBucketNotFound,
+	}
+
+	for _, perr := range permanent {
+		attempts := 0
+		err := retry(ctx, func(ctx context.Context) error {
+			attempts++
+			return perr
+		})
+		if !errors.Is(err, perr) {
+			t.Fatalf("expected %v, got %v", perr, err)
+		}
+		if attempts != 1 {
+			t.Fatalf("expected %v to not be retried, got %d attempts", perr, attempts)
+		}
+	}
+}
+
+func TestRetryUnapplied(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	attempts := 0
+	err := retryUnapplied(ctx, func(ctx context.Context) error {
+		attempts++
+		return nats.ErrTimeout
+	})
+	if !errors.Is(err, nats.ErrTimeout) || attempts != 1 {
+		t.Fatalf("expected timeouts to not be retried, got %d attempts: %v", attempts, err)
+	}
+
+	attempts = 0
+	err = retryUnapplied(ctx, func(ctx context.Context) error {
+		attempts++
+		if attempts < 2 {
+			return nats.ErrNoResponders
+		}
+		return nil
+	})
+	checkErr(t, err, "retry failed: %v", err)
+	if attempts != 2 {
+		t.Fatalf("expected no responders to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	attempts := 0
+	err := retry(ctx, func(ctx context.Context) error {
+		attempts++
+		return nats.ErrNoResponders
+	})
+	if !errors.Is(err, nats.ErrNoResponders) {
+		t.Fatalf("expected the last error once the context is done, got %v", err)
+	}
+	if attempts < 2 {
+		t.Fatalf("expected several attempts before the context is done, got %d", attempts)
+	}
+	if ctx.Err() == nil {
+		t.Fatalf("expected retries to continue until the context is done")
+	}
+}
+
+func TestRetryRequestTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	ctx = withRequestTimeout(ctx, &connection{requestTimeout: 100 * time.Millisecond})
+
+	attempts := 0
+	start := time.Now()
+	err := retry(ctx, func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			// a request that never gets a response
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+	checkErr(t, err, "retry failed: %v", err)
+	if attempts != 2 {
+		t.Fatalf("expected the hung request to be retried, got %d attempts", attempts)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Fatalf("expected the request timeout to bound the attempt, took %v", time.Since(start))
+	}
+
+	rctx, rcancel := requestContext(withRequestTimeout(context.Background(), &connection{}))
+	defer rcancel()
+	if deadline, ok := rctx.Deadline(); !ok || time.Until(deadline) > defaultRequestTimeout {
+		t.Fatalf("expected the default request timeout when request_timeout is not set")
+	}
+}
@@ -26,10 +26,30 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"os"
 	"strings"
 	"time"
 )
 
+func newTempPEMFile(pemContents string) (filename string, cleanup func(), err error) {
+	file, err := os.CreateTemp("", "*.pem")
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(pemContents)
+	if err != nil {
+		return
+	}
+
+	cleanup = func() {
+		_ = os.Remove(filename)
+	}
+
+	return file.Name(), cleanup, err
+}
+
 func newTLSConfig(caPEM, certPEM, keyPEM string) (*tls.Config, error) {
 	tlsClientConfig := &tls.Config{}
 
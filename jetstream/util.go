@@ -14,73 +14,230 @@
 package jetstream
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"net/url"
 	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/natscontext"
 	"github.com/nats-io/jwt/v2"
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nkeys"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var (
-	connectMu sync.Mutex
+	connectionsMu sync.Mutex
+	connections   []*connection
 )
 
-func parseStreamObjID(id string) (string, error) {
-	if !objIdRegex.MatchString(id) {
-		return "", fmt.Errorf("invalid object store bucket id %q", id)
+// joinID creates a resource id from the names identifying the resource, names are path
+// escaped so the id for key a/b in bucket CONFIG is CONFIG/a%2Fb
+func joinID(names ...string) string {
+	escaped := make([]string, len(names))
+	for i, name := range names {
+		escaped[i] = url.PathEscape(name)
 	}
 
-	matches := objIdRegex.FindStringSubmatch(id)
-	return matches[1], nil
+	return strings.Join(escaped, "/")
 }
 
-func parseStreamKVEntryID(id string) (bucket string, key string, err error) {
-	if !kvEntryIdRegex.MatchString(id) {
-		return "", "", fmt.Errorf("invalid kv entry id %q", id)
+// splitID parses an id made by joinID into its n names, the last name keeps any further /
+// so ids like CONFIG/some/key given when importing do not need escaping
+func splitID(id string, n int, kind string) ([]string, error) {
+	parts := strings.SplitN(id, "/", n)
+	if len(parts) != n {
+		return nil, fmt.Errorf("invalid %s id %q", kind, id)
 	}
 
-	matches := kvEntryIdRegex.FindStringSubmatch(id)
-	return matches[1], matches[2], nil
+	for i, part := range parts {
+		name, err := url.PathUnescape(part)
+		if err != nil || name == "" {
+			return nil, fmt.Errorf("invalid %s id %q", kind, id)
+		}
+		parts[i] = name
+	}
+
+	return parts, nil
+}
+
+// prefixID is the id of resources managing everything under a prefix in a bucket, the prefix is
+// left out when empty as ids can not hold empty names
+func prefixID(bucket string, prefix string) string {
+	if prefix == "" {
+		return joinID(bucket)
+	}
+
+	return joinID(bucket, prefix)
+}
+
+// splitPrefixID parses ids created by prefixID, the prefix may be given unescaped
+func splitPrefixID(id string, kind string) (string, string, error) {
+	bucket, prefix, _ := strings.Cut(id, "/")
+	names, err := splitID(bucket, 1, kind)
+	if err != nil {
+		return "", "", err
+	}
+	bucket = names[0]
+
+	if prefix != "" {
+		names, err = splitID(prefix, 1, kind)
+		if err != nil {
+			return "", "", err
+		}
+		prefix = names[0]
+	}
+
+	return bucket, prefix, nil
+}
+
+// importID accepts simple ids like ORDERS/NEW and the legacy ids matched by legacy, if any, when
+// importing, the resource is stored with the id joinID would create for it
+func importID(kind string, n int, legacy *regexp.Regexp) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+			var names []string
+			if legacy != nil {
+				names = legacy.FindStringSubmatch(d.Id())
+			}
+			if names != nil {
+				names = names[1:]
+			} else {
+				var err error
+				names, err = splitID(d.Id(), n, kind)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			d.SetId(joinID(names...))
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
 }
 
-func parseStreamKVID(id string) (string, error) {
-	if !kvIdRegex.MatchString(id) {
-		return "", fmt.Errorf("invalid kv bucket id %q", id)
+// upgradeID is a state upgrader that recreates the id from the names held in keys
+func upgradeID(keys ...string) schema.StateUpgradeFunc {
+	return func(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+		if rawState == nil {
+			return rawState, nil
+		}
+
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			name, _ := rawState[k].(string)
+			if name == "" {
+				return nil, fmt.Errorf("cannot upgrade id %v, %s is not set", rawState["id"], k)
+			}
+			names[i] = name
+		}
+
+		rawState["id"] = joinID(names...)
+
+		return rawState, nil
+	}
+}
+
+func streamID(name string) string { return joinID(name) }
+
+func consumerID(stream string, consumer string) string { return joinID(stream, consumer) }
+
+func kvBucketID(name string) string { return joinID(name) }
+
+func kvEntryID(bucket string, key string) string { return joinID(bucket, key) }
+
+func objBucketID(name string) string { return joinID(name) }
+
+func parseObjBucketID(id string) (string, error) {
+	names, err := splitID(id, 1, "object store bucket")
+	if err != nil {
+		return "", err
+	}
+
+	return names[0], nil
+}
+
+func parseKVEntryID(id string) (bucket string, key string, err error) {
+	names, err := splitID(id, 2, "kv entry")
+	if err != nil {
+		return "", "", err
+	}
+
+	return names[0], names[1], nil
+}
+
+func parseKVBucketID(id string) (string, error) {
+	names, err := splitID(id, 1, "kv bucket")
+	if err != nil {
+		return "", err
 	}
 
-	matches := kvIdRegex.FindStringSubmatch(id)
-	return matches[1], nil
+	return names[0], nil
 }
 
 func parseStreamID(id string) (string, error) {
-	if !streamIdRegex.MatchString(id) {
-		return "", fmt.Errorf("invalid stream id %q", id)
+	names, err := splitID(id, 1, "stream")
+	if err != nil {
+		return "", err
 	}
 
-	matches := streamIdRegex.FindStringSubmatch(id)
+	return names[0], nil
+}
+
+// parseStreamReference parses the stream_id of a consumer, which may also be a legacy stream id
+func parseStreamReference(id string) (string, error) {
+	matches := legacyStreamIdRegex.FindStringSubmatch(id)
+	if matches != nil {
+		return matches[1], nil
+	}
 
-	return matches[1], nil
+	return parseStreamID(id)
 }
 
 func parseConsumerID(id string) (stream string, consumer string, err error) {
-	if !consumerIdRegex.MatchString(id) {
-		return "", "", fmt.Errorf("invalid consumer id %q", id)
+	names, err := splitID(id, 2, "consumer")
+	if err != nil {
+		return "", "", err
 	}
 
-	matches := consumerIdRegex.FindStringSubmatch(id)
+	return names[0], names[1], nil
+}
 
-	return matches[1], matches[2], nil
+// suppressEquivalentStreamID suppresses differences between legacy and current ids of the same stream
+func suppressEquivalentStreamID(_, old, new string, _ *schema.ResourceData) bool {
+	o, err := parseStreamReference(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseStreamReference(new)
+	if err != nil {
+		return false
+	}
+
+	return o == n
 }
 
 func validateCompressionTypeString(i interface{}, p cty.Path) diag.Diagnostics {
@@ -110,6 +267,285 @@ func validateDiscardPolicy() schema.SchemaValidateDiagFunc {
 	return validation.ToDiagFunc(validation.StringInSlice([]string{"old", "new"}, false))
 }
 
+// parseDuration parses a Go duration like 1h30m, bare integers are seconds to remain compatible with
+// configurations written when durations were integers
+func parseDuration(v string) (time.Duration, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, nil
+	}
+
+	secs, err := strconv.ParseInt(v, 10, 64)
+	if err == nil {
+		return time.Duration(secs) * time.Second, nil
+	}
+
+	return time.ParseDuration(v)
+}
+
+// formatDuration renders whole seconds as an integer and anything else as a Go duration
+func formatDuration(v time.Duration) string {
+	if v%time.Second == 0 {
+		return strconv.FormatInt(int64(v/time.Second), 10)
+	}
+
+	return v.String()
+}
+
+// parseByteSize parses sizes like 10GiB or 1.5MB, bare integers are bytes and -1 means unlimited
+func parseByteSize(v string) (int64, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err == nil {
+		return n, nil
+	}
+
+	size, err := humanize.ParseBytes(v)
+	if err != nil {
+		return 0, err
+	}
+	if size > math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is too large", v)
+	}
+
+	return int64(size), nil
+}
+
+func formatByteSize(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func validateDuration() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(i any, k string) ([]string, []error) {
+		v, err := parseDuration(i.(string))
+		if err != nil {
+			return nil, []error{fmt.Errorf("%s must be a duration like 1h30m or a number of seconds: %w", k, err)}
+		}
+		if v < 0 {
+			return nil, []error{fmt.Errorf("%s can not be negative", k)}
+		}
+
+		return nil, nil
+	})
+}
+
+func validateByteSize() schema.SchemaValidateDiagFunc {
+	return validateByteSizeAtMost(math.MaxInt64)
+}
+
+// validateByteSizeAtMost validates sizes for settings the server holds in a smaller integer, like the 32 bit message sizes
+func validateByteSizeAtMost(limit int64) schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(i any, k string) ([]string, []error) {
+		v, err := parseByteSize(i.(string))
+		if err != nil {
+			return nil, []error{fmt.Errorf("%s must be a size like 10GiB or a number of bytes: %w", k, err)}
+		}
+		if v < -1 {
+			return nil, []error{fmt.Errorf("%s must be -1 for unlimited or a positive size", k)}
+		}
+		if v > limit {
+			return nil, []error{fmt.Errorf("%s can not be larger than %d bytes", k, limit)}
+		}
+
+		return nil, nil
+	})
+}
+
+func suppressEquivalentDuration(_, old, new string, _ *schema.ResourceData) bool {
+	// an empty old value means the attribute is being set for the first time, suppressing
+	// that would leave values like 0 out of the plan and the state
+	if old == "" {
+		return new == ""
+	}
+
+	o, err := parseDuration(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseDuration(new)
+	if err != nil {
+		return false
+	}
+
+	return o == n
+}
+
+func suppressEquivalentByteSize(_, old, new string, _ *schema.ResourceData) bool {
+	// an empty old value means the attribute is being set for the first time, suppressing
+	// that would leave values like 0 out of the plan and the state
+	if old == "" {
+		return new == ""
+	}
+
+	o, err := parseByteSize(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseByteSize(new)
+	if err != nil {
+		return false
+	}
+
+	return o == n
+}
+
+// getDuration gets an attribute validated with validateDuration
+func getDuration(d *schema.ResourceData, key string) time.Duration {
+	v, _ := parseDuration(d.Get(key).(string))
+	return v
+}
+
+// getByteSize gets an attribute validated with validateByteSize
+func getByteSize(d *schema.ResourceData, key string) int64 {
+	v, _ := parseByteSize(d.Get(key).(string))
+	return v
+}
+
+// setDuration keeps the configured form of a duration when it is equivalent to v, avoiding
+// needless differences between the state and configurations like 1h versus 3600
+func setDuration(d *schema.ResourceData, key string, v time.Duration) error {
+	if cur, ok := d.Get(key).(string); ok {
+		if parsed, err := parseDuration(cur); err == nil && parsed == v {
+			return nil
+		}
+	}
+
+	return d.Set(key, formatDuration(v))
+}
+
+// setByteSize keeps the configured form of a size when it is equivalent to v
+func setByteSize(d *schema.ResourceData, key string, v int64) error {
+	if cur, ok := d.Get(key).(string); ok {
+		if parsed, err := parseByteSize(cur); err == nil && parsed == v {
+			return nil
+		}
+	}
+
+	return d.Set(key, formatByteSize(v))
+}
+
+// upgradeNumbersToStrings is a state upgrader for attributes that changed from numbers to strings,
+// lists of numbers are converted element by element
+func upgradeNumbersToStrings(keys ...string) schema.StateUpgradeFunc {
+	convert := func(v any) any {
+		switch n := v.(type) {
+		case float64:
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		case int:
+			return strconv.Itoa(n)
+		case json.Number:
+			return n.String()
+		default:
+			return v
+		}
+	}
+
+	return func(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+		if rawState == nil {
+			return rawState, nil
+		}
+
+		for _, k := range keys {
+			switch v := rawState[k].(type) {
+			case nil:
+			case []any:
+				for i := range v {
+					v[i] = convert(v[i])
+				}
+			default:
+				rawState[k] = convert(v)
+			}
+		}
+
+		return rawState, nil
+	}
+}
+
+// upgradeNanosecondsToDurations is a state upgrader for attributes that were stored as a number of
+// nanoseconds and are now duration strings
+func upgradeNanosecondsToDurations(keys ...string) schema.StateUpgradeFunc {
+	return func(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+		if rawState == nil {
+			return rawState, nil
+		}
+
+		for _, k := range keys {
+			var ns int64
+			switch n := rawState[k].(type) {
+			case float64:
+				ns = int64(n)
+			case int:
+				ns = int64(n)
+			case json.Number:
+				v, err := n.Int64()
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s: %w", k, err)
+				}
+				ns = v
+			default:
+				continue
+			}
+
+			rawState[k] = formatDuration(time.Duration(ns))
+		}
+
+		return rawState, nil
+	}
+}
+
+// numberAttributesType is the state type of r before keys changed from numbers to strings
+func numberAttributesType(r *schema.Resource, keys ...string) cty.Type {
+	current := r.CoreConfigSchema().ImpliedType().AttributeTypes()
+
+	attrs := make(map[string]cty.Type, len(current))
+	for k, t := range current {
+		attrs[k] = t
+	}
+
+	for _, k := range keys {
+		t, ok := attrs[k]
+		if !ok {
+			continue
+		}
+
+		if t.IsListType() {
+			attrs[k] = cty.List(cty.Number)
+		} else {
+			attrs[k] = cty.Number
+		}
+	}
+
+	return cty.Object(attrs)
+}
+
+// stateUpgraders upgrades version 0 state, where the units attributes were numbers and ids
+// were prefixed strings, upgrades run before the units are converted so they see the stored numbers
+func stateUpgraders(r *schema.Resource, units []string, upgrades ...schema.StateUpgradeFunc) []schema.StateUpgrader {
+	upgrades = append(upgrades, upgradeNumbersToStrings(units...))
+
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    numberAttributesType(r, units...),
+			Upgrade: func(ctx context.Context, rawState map[string]any, m any) (map[string]any, error) {
+				var err error
+				for _, upgrade := range upgrades {
+					rawState, err = upgrade(ctx, rawState, m)
+					if err != nil {
+						return nil, err
+					}
+				}
+
+				return rawState, nil
+			},
+		},
+	}
+}
+
 func validateStorageTypeString() schema.SchemaValidateDiagFunc {
 	return validation.ToDiagFunc(validation.StringInSlice([]string{"file", "memory"}, false))
 }
@@ -148,12 +584,19 @@ func streamSourceFromResourceData(d any) ([]*api.StreamSource, error) {
 		}
 
 		exts := s["external"].([]any)
-		if len(exts) > 0 {
+		if len(exts) > 0 && exts[0] != nil {
 			ext := exts[0].(map[string]any)
 			source.External = &api.ExternalStream{
 				ApiPrefix:     ext["api"].(string),
 				DeliverPrefix: ext["deliver"].(string),
 			}
+
+			if domain := ext["domain"].(string); domain != "" {
+				if source.External.ApiPrefix != "" {
+					return nil, fmt.Errorf("only one of api and domain may be set for external source %q", source.Name)
+				}
+				source.External.ApiPrefix = externalDomainApiPrefix(domain)
+			}
 		}
 
 		if cs, ok := s["consumer"].([]any); ok && len(cs) > 0 {
@@ -181,6 +624,11 @@ func streamSourceFromResourceData(d any) ([]*api.StreamSource, error) {
 	return res, nil
 }
 
+// externalDomainApiPrefix is the JetStream API prefix for the given domain
+func externalDomainApiPrefix(domain string) string {
+	return fmt.Sprintf("$JS.%s.API", domain)
+}
+
 func streamConfigFromResourceData(d *schema.ResourceData) (cfg api.StreamConfig, requiredAPILevel uint, err error) {
 	var retention api.RetentionPolicy
 	var storage api.StorageType
@@ -257,10 +705,10 @@ func streamConfigFromResourceData(d *schema.ResourceData) (cfg api.StreamConfig,
 		DiscardNewPer:          d.Get("discard_new_per_subject").(bool),
 		MaxConsumers:           d.Get("max_consumers").(int),
 		MaxMsgs:                int64(d.Get("max_msgs").(int)),
-		MaxBytes:               int64(d.Get("max_bytes").(int)),
-		MaxAge:                 time.Second * time.Duration(d.Get("max_age").(int)),
-		Duplicates:             time.Second * time.Duration(d.Get("duplicate_window").(int)),
-		MaxMsgSize:             int32(d.Get("max_msg_size").(int)),
+		MaxBytes:               getByteSize(d, "max_bytes"),
+		MaxAge:                 getDuration(d, "max_age"),
+		Duplicates:             getDuration(d, "duplicate_window"),
+		MaxMsgSize:             int32(getByteSize(d, "max_msg_size")),
 		Storage:                storage,
 		Replicas:               d.Get("replicas").(int),
 		NoAck:                  !d.Get("ack").(bool),
@@ -270,7 +718,7 @@ func streamConfigFromResourceData(d *schema.ResourceData) (cfg api.StreamConfig,
 		RollupAllowed:          d.Get("allow_rollup_hdrs").(bool),
 		Placement:              placement,
 		AllowMsgTTL:            d.Get("allow_msg_ttl").(bool),
-		SubjectDeleteMarkerTTL: time.Second * time.Duration(d.Get("subject_delete_marker_ttl").(int)),
+		SubjectDeleteMarkerTTL: getDuration(d, "subject_delete_marker_ttl"),
 		SubjectTransform:       subjectTransforms,
 		FirstSeq:               uint64(d.Get("first_seq").(int)),
 		AllowBatchPublish:      d.Get("allow_batched").(bool),
@@ -377,10 +825,7 @@ func streamConfigFromResourceData(d *schema.ResourceData) (cfg api.StreamConfig,
 		stream.ConsumerLimits.MaxAckPending = max_ack_pending.(int)
 	}
 
-	inactive_threshold, ok := d.GetOk("inactive_threshold")
-	if ok {
-		stream.ConsumerLimits.InactiveThreshold = time.Second * time.Duration(inactive_threshold.(int))
-	}
+	stream.ConsumerLimits.InactiveThreshold = getDuration(d, "inactive_threshold")
 
 	stream.AllowMsgCounter = d.Get("allow_msg_counter").(bool)
 
@@ -421,23 +866,49 @@ func streamConfigFromResourceData(d *schema.ResourceData) (cfg api.StreamConfig,
 	return stream, requiredAPILevel, nil
 }
 
-func newTempPEMFile(pemContents string) (filename string, cleanup func(), err error) {
-	file, err := os.CreateTemp("", "*.pem")
-	if err != nil {
-		return
-	}
-	defer file.Close()
+// seedSigner signs nonces using an nkey seed, the key pair is wiped after every use
+func seedSigner(seed []byte) nats.SignatureHandler {
+	return func(nonce []byte) ([]byte, error) {
+		kp, err := nkeys.FromSeed(seed)
+		if err != nil {
+			return nil, err
+		}
+		defer kp.Wipe()
 
-	_, err = file.WriteString(pemContents)
-	if err != nil {
-		return
+		return kp.Sign(nonce)
 	}
+}
 
-	cleanup = func() {
-		_ = os.Remove(filename)
-	}
+// commandSigner signs nonces using an external command, the nonce is written to its standard
+// input and the base64 encoded signature is read from its standard output
+func commandSigner(command []string) nats.SignatureHandler {
+	return func(nonce []byte) ([]byte, error) {
+		var stdout, stderr bytes.Buffer
 
-	return file.Name(), cleanup, err
+		cmd := exec.Command(command[0], command[1:]...)
+		cmd.Stdin = bytes.NewReader(nonce)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		err := cmd.Run()
+		if err != nil {
+			return nil, fmt.Errorf("signer command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		out := strings.TrimSpace(stdout.String())
+		if out == "" {
+			return nil, fmt.Errorf("signer command produced no signature")
+		}
+
+		for _, enc := range []*base64.Encoding{base64.RawURLEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.StdEncoding} {
+			sig, err := enc.DecodeString(out)
+			if err == nil {
+				return sig, nil
+			}
+		}
+
+		return nil, fmt.Errorf("signer command produced an invalid signature, expected base64 encoded data")
+	}
 }
 
 func wipeSlice(buf []byte) {
@@ -447,37 +918,62 @@ func wipeSlice(buf []byte) {
 }
 
 type connectProperties struct {
-	creds           string
-	credData        []byte
-	servers         string
-	user            string
-	pass            string
-	nkey            string
-	caFile          string
-	certFile        string
-	keyFile         string
-	cleanupCaFile   func()
-	cleanupCertFile func()
-	cleanupKeyFile  func()
+	creds          string
+	credData       []byte
+	servers        string
+	user           string
+	pass           string
+	nkey           string
+	caFile         string
+	certFile       string
+	keyFile        string
+	domain         string
+	apiPrefix      string
+	token          string
+	nkeyData       []byte
+	userJWT        string
+	signerCommand  []string
+	inboxPrefix    string
+	caData         string
+	certData       string
+	keyData        string
+	systemRoots    bool
+	insecureTLS    bool
+	tlsServerName  string
+	tlsMinVersion  string
+	handshakeFirst bool
+	requestTimeout time.Duration
+	connectTimeout time.Duration
 }
 
 func getConnectProperties(d *schema.ResourceData) (*connectProperties, error) {
-	connectMu.Lock()
-	defer connectMu.Unlock()
-
 	p := connectProperties{
-		creds:           "",
-		credData:        nil,
-		servers:         "",
-		user:            "",
-		pass:            "",
-		nkey:            "",
-		caFile:          "",
-		certFile:        "",
-		keyFile:         "",
-		cleanupCaFile:   nil,
-		cleanupCertFile: nil,
-		cleanupKeyFile:  nil,
+		creds:          "",
+		credData:       nil,
+		servers:        "",
+		user:           "",
+		pass:           "",
+		nkey:           "",
+		caFile:         "",
+		certFile:       "",
+		keyFile:        "",
+		domain:         "",
+		apiPrefix:      "",
+		token:          "",
+		nkeyData:       nil,
+		userJWT:        "",
+		signerCommand:  nil,
+		inboxPrefix:    "",
+		caData:         "",
+		certData:       "",
+		keyData:        "",
+		systemRoots:    false,
+		insecureTLS:    false,
+		tlsServerName:  "",
+		tlsMinVersion:  "",
+		handshakeFirst: false,
+		requestTimeout: 0,
+		connectTimeout: 0,
 	}
 
 	s := d.Get("credentials")
@@ -510,6 +1006,56 @@ func getConnectProperties(d *schema.ResourceData) (*connectProperties, error) {
 		p.nkey = s.(string)
 	}
 
+	s = d.Get("token")
+	if s != nil {
+		p.token = s.(string)
+	}
+
+	s = d.Get("nkey_data")
+	if s != nil && s.(string) != "" {
+		p.nkeyData = []byte(s.(string))
+	}
+
+	s = d.Get("user_jwt")
+	if s != nil {
+		p.userJWT = s.(string)
+	}
+
+	s = d.Get("signer_command")
+	if s != nil {
+		for _, arg := range s.([]any) {
+			p.signerCommand = append(p.signerCommand, arg.(string))
+		}
+	}
+
+	s = d.Get("jetstream_domain")
+	if s != nil {
+		p.domain = s.(string)
+	}
+
+	s = d.Get("jetstream_api_prefix")
+	if s != nil {
+		p.apiPrefix = s.(string)
+	}
+
+	s = d.Get("request_timeout")
+	if s != nil {
+		v, err := parseDuration(s.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid request_timeout: %w", err)
+		}
+		p.requestTimeout = v
+	}
+
+	s = d.Get("connect_timeout")
+	if s != nil {
+		v, err := parseDuration(s.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid connect_timeout: %w", err)
+		}
+		p.connectTimeout = v
+	}
+
 	s = d.Get("tls")
 	if s != nil {
 		set := s.(*schema.Set)
@@ -517,152 +1063,493 @@ func getConnectProperties(d *schema.ResourceData) (*connectProperties, error) {
 		for _, v := range set.List() {
 			m := v.(map[string]any)
 
-			for k, v := range m {
-				switch {
-				case k == "ca_file" && len(v.(string)) > 0:
-					p.caFile = v.(string)
+			p.caFile = m["ca_file"].(string)
+			p.caData = m["ca_file_data"].(string)
+			p.certFile = m["cert_file"].(string)
+			p.certData = m["cert_file_data"].(string)
+			p.keyFile = m["key_file"].(string)
+			p.keyData = m["key_file_data"].(string)
+			p.systemRoots = m["include_system_roots"].(bool)
+			p.insecureTLS = m["insecure_skip_verify"].(bool)
+			p.tlsServerName = m["server_name"].(string)
+			p.tlsMinVersion = m["min_version"].(string)
+			p.handshakeFirst = m["handshake_first"].(bool)
+		}
+	}
 
-				case k == "ca_file_data" && len(v.(string)) > 0:
-					file, cleanup, err := newTempPEMFile(v.(string))
-					if err != nil {
-						return nil, err
-					}
+	s = d.Get("context")
+	if s != nil && s.(string) != "" {
+		err := applyNatsContext(&p, s.(string))
+		if err != nil {
+			return nil, err
+		}
+	}
 
-					p.cleanupCaFile = cleanup
-					p.caFile = file
+	if p.servers == "" {
+		return nil, fmt.Errorf("servers must be set either directly or via a NATS context")
+	}
 
-				case k == "cert_file" && len(v.(string)) > 0:
-					p.certFile = v.(string)
+	return &p, nil
+}
 
-				case k == "cert_file_data" && len(v.(string)) > 0:
-					file, cleanup, err := newTempPEMFile(v.(string))
-					if err != nil {
-						return nil, err
-					}
+// applyNatsContext fills in settings from a NATS CLI context, settings that are already set take precedence.
+// The context is treated as a single source for authentication and JetStream settings, when any of those are
+// already set the context values for that group are ignored
+func applyNatsContext(p *connectProperties, name string) error {
+	var nctx *natscontext.Context
+	var err error
+
+	if strings.HasSuffix(name, ".json") || strings.ContainsRune(name, os.PathSeparator) {
+		nctx, err = natscontext.NewFromFile(name)
+	} else {
+		nctx, err = natscontext.New(name, true)
+	}
+	if err != nil {
+		return fmt.Errorf("could not load NATS context %q: %w", name, err)
+	}
 
-					p.cleanupCertFile = cleanup
-					p.certFile = file
+	if p.servers == "" {
+		p.servers = nctx.ServerURL()
+	}
 
-				case k == "key_file" && len(v.(string)) > 0:
-					p.keyFile = v.(string)
+	hasAuth := p.creds != "" || len(p.credData) > 0 || p.user != "" || p.pass != "" || p.nkey != "" || len(p.nkeyData) > 0 || p.token != "" || p.userJWT != ""
+	if !hasAuth {
+		p.creds = nctx.Creds()
+		p.nkey = nctx.NKey()
+		p.user = nctx.User()
+		p.pass = nctx.Password()
+		p.token = nctx.Token()
+		p.userJWT = nctx.UserJWT()
+		if seed := nctx.UserSeed(); seed != "" {
+			p.nkeyData = []byte(seed)
+		}
+	}
 
-				case k == "key_file_data" && len(v.(string)) > 0:
-					file, cleanup, err := newTempPEMFile(v.(string))
-					if err != nil {
-						return nil, err
-					}
+	if p.caFile == "" && p.caData == "" {
+		p.caFile = nctx.CA()
+	}
 
-					p.cleanupKeyFile = cleanup
-					p.keyFile = file
-				}
+	if p.certFile == "" && p.certData == "" && p.keyFile == "" && p.keyData == "" {
+		p.certFile = nctx.Certificate()
+		p.keyFile = nctx.Key()
+	}
+
+	if nctx.TLSHandshakeFirst() {
+		p.handshakeFirst = true
+	}
+
+	if p.inboxPrefix == "" {
+		p.inboxPrefix = nctx.InboxPrefix()
+	}
+
+	if p.domain == "" && p.apiPrefix == "" {
+		p.domain = nctx.JSDomain()
+		p.apiPrefix = nctx.JSAPIPrefix()
+	}
+
+	return nil
+}
+
+// tlsConfigFromProperties builds the TLS configuration in memory so that key material never touches the
+// disk, returns nil when no TLS settings were given
+func tlsConfigFromProperties(p *connectProperties) (*tls.Config, error) {
+	hasCA := p.caFile != "" || p.caData != ""
+	hasCert := p.certFile != "" || p.certData != ""
+	hasKey := p.keyFile != "" || p.keyData != ""
+
+	if !hasCA && !hasCert && !hasKey && !p.systemRoots && !p.insecureTLS && p.tlsServerName == "" && p.tlsMinVersion == "" && !p.handshakeFirst {
+		return nil, nil
+	}
+
+	tlsc := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: p.insecureTLS,
+		ServerName:         p.tlsServerName,
+	}
+
+	switch p.tlsMinVersion {
+	case "", "1.2":
+	case "1.3":
+		tlsc.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported TLS min_version %q", p.tlsMinVersion)
+	}
+
+	if hasCA {
+		var pool *x509.CertPool
+		if p.systemRoots {
+			sp, err := x509.SystemCertPool()
+			if err != nil {
+				return nil, fmt.Errorf("could not load system root CAs: %w", err)
+			}
+			pool = sp
+		} else {
+			pool = x509.NewCertPool()
+		}
+
+		caPEM := []byte(p.caData)
+		if p.caFile != "" {
+			pem, err := os.ReadFile(p.caFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read ca_file: %w", err)
+			}
+			caPEM = append(caPEM, '\n')
+			caPEM = append(caPEM, pem...)
+		}
+
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("could not parse any root CA certificates")
+		}
+
+		tlsc.RootCAs = pool
+	}
+
+	if hasCert || hasKey {
+		if !hasCert || !hasKey {
+			return nil, fmt.Errorf("cert_file and key_file depend on each other, if one is provided the other one must be provided as well")
+		}
+
+		certPEM := []byte(p.certData)
+		if p.certFile != "" {
+			pem, err := os.ReadFile(p.certFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read cert_file: %w", err)
+			}
+			certPEM = pem
+		}
+
+		keyPEM := []byte(p.keyData)
+		if p.keyFile != "" {
+			pem, err := os.ReadFile(p.keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read key_file: %w", err)
 			}
+			keyPEM = pem
 		}
+		defer wipeSlice(keyPEM)
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+
+		tlsc.Certificates = []tls.Certificate{cert}
 	}
 
-	return &p, nil
+	return tlsc, nil
 }
 
-func getConnection(d *schema.ResourceData, m any) (*nats.Conn, error) {
-	nc, _, err := m.(func() (*nats.Conn, *jsm.Manager, error))()
+// dataSourceSchemaFromResource derives a data source schema from a resource schema, all attributes
+// become computed except the lookup keys which are required, skipped attributes are left out
+func dataSourceSchemaFromResource(rs map[string]*schema.Schema, required []string, skip ...string) map[string]*schema.Schema {
+	ds := make(map[string]*schema.Schema, len(rs))
+
+	for k, v := range rs {
+		if slices.Contains(skip, k) {
+			continue
+		}
+
+		ds[k] = computedSchema(v)
+	}
+
+	for _, k := range required {
+		ds[k].Computed = false
+		ds[k].Required = true
+	}
+
+	return ds
+}
+
+func computedSchema(s *schema.Schema) *schema.Schema {
+	c := &schema.Schema{
+		Type:        s.Type,
+		Description: s.Description,
+		Computed:    true,
+		Sensitive:   s.Sensitive,
+	}
+
+	switch elem := s.Elem.(type) {
+	case *schema.Schema:
+		c.Elem = &schema.Schema{Type: elem.Type}
+	case *schema.Resource:
+		c.Elem = &schema.Resource{Schema: dataSourceSchemaFromResource(elem.Schema, nil)}
+	}
+
+	return c
+}
+
+// defaultOperationTimeout bounds resource operations, including any retries and waits, unless
+// a timeouts block is set on the resource
+const defaultOperationTimeout = 5 * time.Minute
+
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultOperationTimeout),
+		Read:   schema.DefaultTimeout(defaultOperationTimeout),
+		Update: schema.DefaultTimeout(defaultOperationTimeout),
+		Delete: schema.DefaultTimeout(defaultOperationTimeout),
+	}
+}
+
+func getConnection(m any) (*nats.Conn, error) {
+	nc, _, err := m.(*connection).connect()
 	return nc, err
 }
 
-func connectMgr(d *schema.ResourceData) (any, error) {
-	return func() (*nats.Conn, *jsm.Manager, error) {
-		props, err := getConnectProperties(d)
-		if err != nil {
-			return nil, nil, err
-		}
+func getManager(m any) (*jsm.Manager, error) {
+	_, mgr, err := m.(*connection).connect()
+	return mgr, err
+}
 
-		var opts []nats.Option
+func getJetStream(m any) (jetstream.JetStream, error) {
+	c := m.(*connection)
 
-		switch {
-		case props.creds != "":
-			opts = append(opts, nats.UserCredentials(props.creds))
+	_, _, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
 
-		case len(props.credData) > 0:
-			defer wipeSlice(props.credData)
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-			userCB := func() (string, error) {
-				return jwt.ParseDecoratedJWT(props.credData)
-			}
+	return c.js, nil
+}
 
-			sigCB := func(nonce []byte) ([]byte, error) {
-				kp, err := jwt.ParseDecoratedNKey(props.credData)
-				if err != nil {
-					return nil, err
-				}
-				defer kp.Wipe()
+// connection is the provider scoped NATS connection shared by all resources, it is
+// established on first use and re-established should it ever be closed
+type connection struct {
+	d   *schema.ResourceData
+	nc  *nats.Conn
+	mgr *jsm.Manager
+	js  jetstream.JetStream
+	mu  sync.Mutex
+
+	// requestTimeout bounds each request made through the retry helpers
+	requestTimeout time.Duration
+}
 
-				return kp.Sign(nonce)
-			}
+// CloseConnections closes all connections made by configured providers, called when the plugin shuts down
+func CloseConnections() {
+	connectionsMu.Lock()
+	defer connectionsMu.Unlock()
+
+	for _, c := range connections {
+		c.close()
+	}
+
+	connections = nil
+}
+
+func (c *connection) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.nc != nil {
+		c.nc.Close()
+	}
+
+	c.nc = nil
+	c.mgr = nil
+	c.js = nil
+}
+
+func (c *connection) connect() (*nats.Conn, *jsm.Manager, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.nc != nil && !c.nc.IsClosed() {
+		return c.nc, c.mgr, nil
+	}
 
-			opts = append(opts, nats.UserJWT(userCB, sigCB))
+	props, err := getConnectProperties(c.d)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var opts []nats.Option
+
+	// inline secrets are needed again when reconnecting so they are kept until the connection closes
+	var secrets [][]byte
+	wipeSecrets := func() {
+		for _, secret := range secrets {
+			wipeSlice(secret)
 		}
+	}
 
-		switch {
-		case props.user != "" && props.pass != "":
-			opts = append(opts, nats.UserInfo(props.user, props.pass))
-		case props.user != "":
-			opts = append(opts, nats.Token(props.user))
-		case props.nkey != "":
-			nko, err := nats.NkeyOptionFromSeed(props.nkey)
-			if err != nil {
-				return nil, nil, err
-			}
+	switch {
+	case props.creds != "":
+		opts = append(opts, nats.UserCredentials(props.creds))
+
+	case len(props.credData) > 0:
+		credData := props.credData
+		secrets = append(secrets, credData)
 
-			opts = append(opts, nko)
+		userCB := func() (string, error) {
+			return jwt.ParseDecoratedJWT(credData)
 		}
 
-		if len(props.caFile) > 0 {
-			if props.cleanupCaFile != nil {
-				defer props.cleanupCaFile()
+		sigCB := func(nonce []byte) ([]byte, error) {
+			kp, err := jwt.ParseDecoratedNKey(credData)
+			if err != nil {
+				return nil, err
 			}
+			defer kp.Wipe()
 
-			opts = append(opts, nats.RootCAs(props.caFile))
+			return kp.Sign(nonce)
 		}
 
-		if len(props.certFile) > 0 || len(props.keyFile) > 0 {
-			if props.cleanupCertFile != nil {
-				defer props.cleanupCertFile()
-			}
+		opts = append(opts, nats.UserJWT(userCB, sigCB))
 
-			if props.cleanupKeyFile != nil {
-				defer props.cleanupKeyFile()
-			}
+	case props.userJWT != "" && len(props.signerCommand) > 0:
+		userJWT := props.userJWT
+		userCB := func() (string, error) {
+			return userJWT, nil
+		}
 
-			if len(props.certFile) == 0 || len(props.keyFile) == 0 {
-				return nil, nil, fmt.Errorf("cert_file and key_file depend on each other, if one is provided the other one must be provided as well")
-			}
+		opts = append(opts, nats.UserJWT(userCB, commandSigner(props.signerCommand)))
 
-			opts = append(opts, nats.ClientCert(props.certFile, props.keyFile))
+	case props.userJWT != "" && len(props.nkeyData) > 0:
+		seed := props.nkeyData
+		secrets = append(secrets, seed)
+
+		userJWT := props.userJWT
+		userCB := func() (string, error) {
+			return userJWT, nil
 		}
 
-		nc, err := nats.Connect(props.servers, opts...)
+		opts = append(opts, nats.UserJWT(userCB, seedSigner(seed)))
+
+	case props.userJWT != "":
+		return nil, nil, fmt.Errorf("user_jwt requires either nkey_data or signer_command to be set")
+	}
+
+	switch {
+	case props.userJWT != "":
+	case props.user != "" && props.pass != "":
+		opts = append(opts, nats.UserInfo(props.user, props.pass))
+	case props.user != "":
+		opts = append(opts, nats.Token(props.user))
+	case props.token != "":
+		opts = append(opts, nats.Token(props.token))
+	case props.nkey != "":
+		nko, err := nats.NkeyOptionFromSeed(props.nkey)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		mgr, err := jsm.New(nc, jsm.WithAPIValidation(new(SchemaValidator)), jsm.WithPedanticRequests())
+		opts = append(opts, nko)
+	case len(props.nkeyData) > 0:
+		seed := props.nkeyData
+		secrets = append(secrets, seed)
+
+		kp, err := nkeys.FromSeed(seed)
 		if err != nil {
-			return nil, nil, err
+			wipeSecrets()
+			return nil, nil, fmt.Errorf("invalid nkey_data: %w", err)
 		}
-
-		apiLevel, err := mgr.MetaApiLevel(true)
+		pub, err := kp.PublicKey()
+		kp.Wipe()
 		if err != nil {
-			return nil, nil, err
+			wipeSecrets()
+			return nil, nil, fmt.Errorf("invalid nkey_data: %w", err)
 		}
 
-		if apiLevel < 1 {
-			return nil, nil, fmt.Errorf("unsupported api level: %d. Requires NATS Server 2.11 or newer", apiLevel)
-		}
+		opts = append(opts, nats.Nkey(pub, seedSigner(seed)))
+	}
+
+	opts = append(opts, nats.ClosedHandler(func(_ *nats.Conn) { wipeSecrets() }))
+
+	tlsc, err := tlsConfigFromProperties(props)
+	if err != nil {
+		wipeSecrets()
+		return nil, nil, err
+	}
+	if tlsc != nil {
+		opts = append(opts, nats.Secure(tlsc))
+	}
+
+	if props.handshakeFirst {
+		opts = append(opts, nats.TLSHandshakeFirst())
+	}
+
+	if props.inboxPrefix != "" {
+		opts = append(opts, nats.CustomInboxPrefix(props.inboxPrefix))
+	}
+
+	// the connection is long lived so we keep trying to reconnect rather than giving up mid apply
+	opts = append(opts, nats.MaxReconnects(-1))
+
+	if props.connectTimeout > 0 {
+		opts = append(opts, nats.Timeout(props.connectTimeout))
+	}
+
+	nc, err := nats.Connect(props.servers, opts...)
+	if err != nil {
+		wipeSecrets()
+		return nil, nil, err
+	}
 
-		return nc, mgr, err
-	}, nil
+	mgrOpts := []jsm.Option{jsm.WithAPIValidation(new(SchemaValidator)), jsm.WithPedanticRequests()}
+
+	// jsm does not take a context so this bounds every stream and consumer API request, requests
+	// made with the jetstream client are bounded by the retry helpers
+	if props.requestTimeout > 0 {
+		mgrOpts = append(mgrOpts, jsm.WithTimeout(props.requestTimeout))
+	}
+
+	var js jetstream.JetStream
+	switch {
+	case props.domain != "":
+		mgrOpts = append(mgrOpts, jsm.WithDomain(props.domain))
+		js, err = jetstream.NewWithDomain(nc, props.domain)
+	case props.apiPrefix != "":
+		mgrOpts = append(mgrOpts, jsm.WithAPIPrefix(props.apiPrefix))
+		js, err = jetstream.NewWithAPIPrefix(nc, props.apiPrefix)
+	default:
+		js, err = jetstream.New(nc)
+	}
+	if err != nil {
+		nc.Close()
+		return nil, nil, err
+	}
+
+	mgr, err := jsm.New(nc, mgrOpts...)
+	if err != nil {
+		nc.Close()
+		return nil, nil, err
+	}
+
+	apiLevel, err := mgr.MetaApiLevel(true)
+	if err != nil {
+		nc.Close()
+		return nil, nil, err
+	}
+
+	if apiLevel < 1 {
+		nc.Close()
+		return nil, nil, fmt.Errorf("unsupported api level: %d. Requires NATS Server 2.11 or newer", apiLevel)
+	}
+
+	c.nc = nc
+	c.mgr = mgr
+	c.js = js
+
+	return nc, mgr, nil
+}
+
+func connectMgr(d *schema.ResourceData) (any, error) {
+	c := &connection{d: d, requestTimeout: getDuration(d, "request_timeout")}
+
+	connectionsMu.Lock()
+	connections = append(connections, c)
+	connectionsMu.Unlock()
+
+	return c, nil
 }
 
 func apiLevel(mgr *jsm.Manager) (uint, error) {
-	apiLevel, err := mgr.MetaApiLevel(true)
+	// the level is fetched when connecting and cached in the manager for the life of the connection
+	apiLevel, err := mgr.MetaApiLevel(false)
 	if err != nil {
 		return 0, err
 	}
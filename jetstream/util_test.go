@@ -14,12 +14,16 @@
 package jetstream
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
@@ -146,6 +150,28 @@ func testStreamSourceHasConsumer(t *testing.T, mgr *jsm.Manager, stream string,
 	}
 }
 
+func testStreamSourceHasApiPrefix(t *testing.T, mgr *jsm.Manager, stream string, sourceName string, prefix string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		str, err := mgr.LoadStream(stream)
+		if err != nil {
+			return err
+		}
+		for _, src := range str.Sources() {
+			if src.Name != sourceName {
+				continue
+			}
+			if src.External == nil {
+				return fmt.Errorf("source %q is not external", sourceName)
+			}
+			if src.External.ApiPrefix != prefix {
+				return fmt.Errorf("source %q api prefix = %q, want %q", sourceName, src.External.ApiPrefix, prefix)
+			}
+			return nil
+		}
+		return fmt.Errorf("stream %q has no source named %q", stream, sourceName)
+	}
+}
+
 func testConsumerHasAckPolicy(t *testing.T, mgr *jsm.Manager, stream string, consumer string, policy api.AckPolicy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		cons, err := mgr.LoadConsumer(stream, consumer)
@@ -160,6 +186,25 @@ func testConsumerHasAckPolicy(t *testing.T, mgr *jsm.Manager, stream string, con
 	}
 }
 
+func testConsumerHasDurations(t *testing.T, mgr *jsm.Manager, stream string, consumer string, ackWait time.Duration, inactive time.Duration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c, err := mgr.LoadConsumer(stream, consumer)
+		if err != nil {
+			return err
+		}
+
+		if c.AckWait() != ackWait {
+			return fmt.Errorf("expected ack wait %v got %v", ackWait, c.AckWait())
+		}
+
+		if c.InactiveThreshold() != inactive {
+			return fmt.Errorf("expected inactive threshold %v got %v", inactive, c.InactiveThreshold())
+		}
+
+		return nil
+	}
+}
+
 func testStreamHasSubjects(t *testing.T, mgr *jsm.Manager, stream string, subjects []string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		str, err := mgr.LoadStream(stream)
@@ -412,3 +457,273 @@ func testStreamIsTransformed(t *testing.T, mgr *jsm.Manager, stream string, tran
 		return nil
 	}
 }
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":       0,
+		"0":      0,
+		"120":    2 * time.Minute,
+		"1h30m":  90 * time.Minute,
+		"500ms":  500 * time.Millisecond,
+		" 10s ":  10 * time.Second,
+		"8760h":  365 * 24 * time.Hour,
+		"1.5s":   1500 * time.Millisecond,
+		"100000": 100000 * time.Second,
+	}
+
+	for in, expected := range cases {
+		v, err := parseDuration(in)
+		checkErr(t, err, "parse %q failed: %v", in, err)
+		if v != expected {
+			t.Fatalf("expected %q to be %v got %v", in, expected, v)
+		}
+	}
+
+	_, err := parseDuration("1 day")
+	if err == nil {
+		t.Fatalf("expected invalid durations to fail")
+	}
+
+	if formatDuration(2*time.Minute) != "120" {
+		t.Fatalf("expected whole seconds to format as an integer, got %q", formatDuration(2*time.Minute))
+	}
+	if formatDuration(1500*time.Millisecond) != "1.5s" {
+		t.Fatalf("expected sub second durations to format as a duration, got %q", formatDuration(1500*time.Millisecond))
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]int64{
+		"":      0,
+		"-1":    -1,
+		"1024":  1024,
+		"1KiB":  1024,
+		"10GiB": 10 * 1024 * 1024 * 1024,
+		"1.5MB": 1500000,
+		"10 MB": 10000000,
+	}
+
+	for in, expected := range cases {
+		v, err := parseByteSize(in)
+		checkErr(t, err, "parse %q failed: %v", in, err)
+		if v != expected {
+			t.Fatalf("expected %q to be %d got %d", in, expected, v)
+		}
+	}
+
+	_, err := parseByteSize("lots")
+	if err == nil {
+		t.Fatalf("expected invalid sizes to fail")
+	}
+}
+
+func TestValidateByteSizeAtMost(t *testing.T) {
+	validate := validateByteSizeAtMost(math.MaxInt32)
+
+	for _, v := range []string{"-1", "1MiB", "2147483647"} {
+		if diags := validate(v, cty.Path{}); diags.HasError() {
+			t.Fatalf("expected %q to be valid: %v", v, diags)
+		}
+	}
+
+	for _, v := range []string{"2GiB", "4GiB", "-2"} {
+		if diags := validate(v, cty.Path{}); !diags.HasError() {
+			t.Fatalf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestUnitsStateUpgrade(t *testing.T) {
+	for _, r := range []*schema.Resource{resourceStream(), resourceConsumer(), resourceKVBucket(), resourceObjBucket()} {
+		err := r.InternalValidate(nil, true)
+		checkErr(t, err, "invalid resource: %v", err)
+	}
+
+	upgrader := resourceConsumer().StateUpgraders[0]
+	if !upgrader.Type.AttributeType("ack_wait").Equals(cty.Number) || !upgrader.Type.AttributeType("backoff").Equals(cty.List(cty.Number)) {
+		t.Fatalf("expected version 0 durations to be numbers")
+	}
+
+	state, err := upgrader.Upgrade(context.Background(), map[string]any{
+		"ack_wait":     float64(30),
+		"backoff":      []any{float64(1), float64(60)},
+		"durable_name": "C1",
+		"id":           "JETSTREAM_STREAM_ORDERS_CONSUMER_C1",
+		"max_bytes":    nil,
+		"stream_id":    "JETSTREAM_STREAM_ORDERS",
+	}, nil)
+	checkErr(t, err, "upgrade failed: %v", err)
+
+	expected := map[string]any{
+		"ack_wait":     "30",
+		"backoff":      []any{"1", "60"},
+		"durable_name": "C1",
+		"id":           "ORDERS/C1",
+		"max_bytes":    nil,
+		"stream_id":    "ORDERS",
+	}
+	if !cmp.Equal(state, expected) {
+		t.Fatalf("unexpected upgraded state: %s", cmp.Diff(expected, state))
+	}
+
+	upgrader = resourceStream().StateUpgraders[0]
+	if !upgrader.Type.AttributeType("inactive_threshold").Equals(cty.Number) {
+		t.Fatalf("expected version 0 inactive_threshold to be a number")
+	}
+
+	state, err = upgrader.Upgrade(context.Background(), map[string]any{
+		"id":                 "JETSTREAM_STREAM_TEST",
+		"inactive_threshold": float64(90 * time.Second),
+		"max_age":            float64(3600),
+		"name":               "TEST",
+	}, nil)
+	checkErr(t, err, "upgrade failed: %v", err)
+
+	expected = map[string]any{
+		"id":                 "TEST",
+		"inactive_threshold": "90",
+		"max_age":            "3600",
+		"name":               "TEST",
+	}
+	if !cmp.Equal(state, expected) {
+		t.Fatalf("unexpected upgraded state: %s", cmp.Diff(expected, state))
+	}
+}
+
+func TestIDs(t *testing.T) {
+	if id := kvEntryID("CONFIG", "app/db.url"); id != "CONFIG/app%2Fdb.url" {
+		t.Fatalf("unexpected id %q", id)
+	}
+
+	bucket, key, err := parseKVEntryID("CONFIG/app%2Fdb.url")
+	checkErr(t, err, "parse failed: %v", err)
+	if bucket != "CONFIG" || key != "app/db.url" {
+		t.Fatalf("unexpected bucket %q and key %q", bucket, key)
+	}
+
+	// names containing the legacy separators are unambiguous
+	stream, consumer, err := parseConsumerID(consumerID("A_CONSUMER_B", "C_CONSUMER_D"))
+	checkErr(t, err, "parse failed: %v", err)
+	if stream != "A_CONSUMER_B" || consumer != "C_CONSUMER_D" {
+		t.Fatalf("unexpected stream %q and consumer %q", stream, consumer)
+	}
+
+	for id, expected := range map[string][2]string{"FILES": {"FILES", ""}, "FILES/site%2F": {"FILES", "site/"}, "FILES/site/": {"FILES", "site/"}} {
+		bucket, prefix, err := splitPrefixID(id, "obj directory")
+		checkErr(t, err, "parse failed: %v", err)
+		if bucket != expected[0] || prefix != expected[1] {
+			t.Fatalf("unexpected bucket %q and prefix %q for %q", bucket, prefix, id)
+		}
+	}
+	if id := prefixID("FILES", "site/"); id != "FILES/site%2F" {
+		t.Fatalf("unexpected id %q", id)
+	}
+
+	for _, id := range []string{"", "ORDERS", "ORDERS/", "/NEW", "ORDERS/%zz"} {
+		_, _, err = parseConsumerID(id)
+		if err == nil {
+			t.Fatalf("expected %q to be invalid", id)
+		}
+	}
+
+	for _, id := range []string{"ORDERS", "JETSTREAM_STREAM_ORDERS"} {
+		stream, err = parseStreamReference(id)
+		checkErr(t, err, "parse failed: %v", err)
+		if stream != "ORDERS" {
+			t.Fatalf("unexpected stream %q for %q", stream, id)
+		}
+	}
+
+	if !suppressEquivalentStreamID("stream_id", "ORDERS", "JETSTREAM_STREAM_ORDERS", nil) {
+		t.Fatalf("expected legacy stream ids to be equivalent")
+	}
+	if suppressEquivalentStreamID("stream_id", "ORDERS", "JETSTREAM_STREAM_OTHER", nil) {
+		t.Fatalf("expected different streams to not be equivalent")
+	}
+}
+
+func TestImportID(t *testing.T) {
+	cases := []struct {
+		resource *schema.Resource
+		id       string
+		expected string
+	}{
+		{resourceStream(), "ORDERS", "ORDERS"},
+		{resourceStream(), "JETSTREAM_STREAM_ORDERS", "ORDERS"},
+		{resourceConsumer(), "ORDERS/NEW", "ORDERS/NEW"},
+		{resourceConsumer(), "JETSTREAM_STREAM_ORDERS_CONSUMER_NEW", "ORDERS/NEW"},
+		{resourceKVBucket(), "JETSTREAM_KV_CONFIG", "CONFIG"},
+		{resourceKVEntry(), "CONFIG/some.key", "CONFIG/some.key"},
+		{resourceKVEntry(), "CONFIG/app/db.url", "CONFIG/app%2Fdb.url"},
+		{resourceKVEntry(), "JETSTREAM_KV_CONFIG_ENTRY_some.key", "CONFIG/some.key"},
+		{resourceObjBucket(), "JETSTREAM_OBJ_FILES", "FILES"},
+	}
+
+	for _, c := range cases {
+		d := c.resource.Data(nil)
+		d.SetId(c.id)
+
+		res, err := c.resource.Importer.StateContext(context.Background(), d, nil)
+		checkErr(t, err, "import of %q failed: %v", c.id, err)
+		if res[0].Id() != c.expected {
+			t.Fatalf("expected %q to import as %q got %q", c.id, c.expected, res[0].Id())
+		}
+	}
+
+	_, err := resourceConsumer().Importer.StateContext(context.Background(), resourceConsumer().Data(nil), nil)
+	if err == nil {
+		t.Fatalf("expected an empty id to fail")
+	}
+}
+
+func TestIDStateUpgrade(t *testing.T) {
+	for _, r := range []*schema.Resource{resourceStream(), resourceConsumer(), resourceKVBucket(), resourceKVEntry(), resourceObjBucket()} {
+		err := r.InternalValidate(nil, true)
+		checkErr(t, err, "invalid resource: %v", err)
+	}
+
+	cases := []struct {
+		upgrader schema.StateUpgrader
+		state    map[string]any
+		expected map[string]any
+	}{
+		{
+			resourceStream().StateUpgraders[0],
+			map[string]any{"id": "JETSTREAM_STREAM_ORDERS", "name": "ORDERS"},
+			map[string]any{"id": "ORDERS", "name": "ORDERS"},
+		},
+		{
+			resourceConsumer().StateUpgraders[0],
+			map[string]any{"id": "JETSTREAM_STREAM_A_CONSUMER_B_CONSUMER_C", "stream_id": "JETSTREAM_STREAM_A_CONSUMER_B", "durable_name": "C"},
+			map[string]any{"id": "A_CONSUMER_B/C", "stream_id": "A_CONSUMER_B", "durable_name": "C"},
+		},
+		{
+			resourceKVBucket().StateUpgraders[0],
+			map[string]any{"id": "JETSTREAM_KV_CONFIG", "name": "CONFIG"},
+			map[string]any{"id": "CONFIG", "name": "CONFIG"},
+		},
+		{
+			resourceKVEntry().StateUpgraders[0],
+			map[string]any{"id": "JETSTREAM_KV_X_ENTRY_Y_ENTRY_app/z", "bucket": "X_ENTRY_Y", "key": "app/z"},
+			map[string]any{"id": "X_ENTRY_Y/app%2Fz", "bucket": "X_ENTRY_Y", "key": "app/z"},
+		},
+		{
+			resourceObjBucket().StateUpgraders[0],
+			map[string]any{"id": "JETSTREAM_OBJ_FILES", "name": "FILES"},
+			map[string]any{"id": "FILES", "name": "FILES"},
+		},
+	}
+
+	for _, c := range cases {
+		state, err := c.upgrader.Upgrade(context.Background(), c.state, nil)
+		checkErr(t, err, "upgrade failed: %v", err)
+		if !cmp.Equal(state, c.expected) {
+			t.Fatalf("unexpected upgraded state: %s", cmp.Diff(c.expected, state))
+		}
+	}
+
+	_, err := resourceKVEntry().StateUpgraders[0].Upgrade(context.Background(), map[string]any{"id": "JETSTREAM_KV_X_ENTRY_Y"}, nil)
+	if err == nil {
+		t.Fatalf("expected an upgrade without names to fail")
+	}
+}
@@ -20,4 +20,5 @@ import (
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{ProviderFunc: jetstream.Provider})
+	jetstream.CloseConnections()
 }